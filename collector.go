@@ -1,11 +1,17 @@
 package main
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/trazfr/freebox-exporter/fbx"
@@ -14,6 +20,9 @@ import (
 
 const (
 	metricPrefix = "freebox_"
+
+	// number of vendors exported by freebox_lan_host_vendor_total. The others are aggregated
+	lanHostTopVendors = 10
 )
 
 var (
@@ -30,14 +39,26 @@ var (
 		metricPrefix+"system_uptime",
 		"freebox uptime (in seconds)",
 		nil, nil)
+	promDescSystemReboots = prometheus.NewDesc(
+		metricPrefix+"system_reboots_total",
+		"number of reboots detected by the exporter (uptime reset)",
+		nil, nil)
+	promDescSystemAuthenticated = prometheus.NewDesc(
+		metricPrefix+"system_authenticated",
+		"1 for the current authentication state of the box, 0 for the others",
+		[]string{"state"}, nil)
+	promDescSystemDiskStatus = prometheus.NewDesc(
+		metricPrefix+"system_disk_status",
+		"1 for the current status of the disk, 0 for the others",
+		[]string{"user_main_storage", "state"}, nil)
 	promDescSystemTemp = prometheus.NewDesc(
 		metricPrefix+"system_temp_degrees",
 		"temperature (°C)",
-		[]string{"id"}, nil)
+		[]string{"id", "name"}, nil)
 	promDescSystemFanRpm = prometheus.NewDesc(
 		metricPrefix+"system_fan_rpm",
 		"fan rpm",
-		[]string{"id"}, nil)
+		[]string{"id", "name"}, nil)
 	promDescConnectionBandwidthBytes = prometheus.NewDesc(
 		metricPrefix+"connection_bandwidth_bytes",
 		"available upload/download bandwidth in bytes/s",
@@ -97,6 +118,51 @@ var (
 		"SFP power report in Dbm",
 		[]string{"sfp_serial", "sfp_model", "sfp_vendor", "dir"}, nil) // rx/tx
 
+	promDescConnectionLteEnabled = prometheus.NewDesc(
+		metricPrefix+"connection_lte_enabled",
+		"1 if the 4G is enabled, 0 if not",
+		nil, nil)
+	promDescConnectionLteState = prometheus.NewDesc(
+		metricPrefix+"connection_lte_state",
+		"1 for the current state of the 4G link, 0 for the others",
+		[]string{"state"}, nil)
+	promDescConnectionLteActive = prometheus.NewDesc(
+		metricPrefix+"connection_lte_active",
+		"1 if the 4G link is currently carrying traffic, 0 if not",
+		nil, nil)
+	promDescConnectionLteRsrp = prometheus.NewDesc(
+		metricPrefix+"connection_lte_rsrp_dbm",
+		"Reference Signal Received Power in dBm",
+		[]string{"band"}, nil)
+	promDescConnectionLteRsrq = prometheus.NewDesc(
+		metricPrefix+"connection_lte_rsrq_db",
+		"Reference Signal Received Quality in dB",
+		[]string{"band"}, nil)
+	promDescConnectionLteRssi = prometheus.NewDesc(
+		metricPrefix+"connection_lte_rssi_dbm",
+		"Received Signal Strength Indicator in dBm",
+		[]string{"band"}, nil)
+	promDescConnectionLteSinr = prometheus.NewDesc(
+		metricPrefix+"connection_lte_sinr_db",
+		"Signal to Interference plus Noise Ratio in dB",
+		[]string{"band"}, nil)
+	promDescConnectionAggregationState = prometheus.NewDesc(
+		metricPrefix+"connection_aggregation_state",
+		"1 for the current state of the aggregation, 0 for the others",
+		[]string{"state"}, nil)
+	promDescConnectionAggregationRateBytes = prometheus.NewDesc(
+		metricPrefix+"connection_aggregation_rate_bytes",
+		"rate used on each link of the aggregation in bytes/s",
+		[]string{"link", "dir"}, nil) // rx/tx
+	promDescConnectionAggregationMaxRateBytes = prometheus.NewDesc(
+		metricPrefix+"connection_aggregation_maxrate_bytes",
+		"max rate of each link of the aggregation in bytes/s",
+		[]string{"link", "dir"}, nil) // rx/tx
+	promDescConnectionAggregationShare = prometheus.NewDesc(
+		metricPrefix+"connection_aggregation_share_ratio",
+		"share of the traffic carried by each link of the aggregation, between 0 and 1",
+		[]string{"link", "dir"}, nil) // rx/tx
+
 	promDescSwitchPortConnectedTotal = prometheus.NewDesc(
 		metricPrefix+"switch_port_connected_total",
 		"number of ports connnected",
@@ -122,6 +188,35 @@ var (
 		"constant metric with value=1. List of MAC addresses connected to the switch",
 		[]string{"id", "mac", "hostname"}, nil)
 
+	promDescWifiEnabled = prometheus.NewDesc(
+		metricPrefix+"wifi_enabled",
+		"1 if the Wi-Fi is enabled, 0 if not",
+		nil, nil)
+	promDescWifiPowerSaving = prometheus.NewDesc(
+		metricPrefix+"wifi_power_saving",
+		"1 if the Wi-Fi power saving is enabled, 0 if not",
+		nil, nil)
+	promDescWifiMacFilterState = prometheus.NewDesc(
+		metricPrefix+"wifi_mac_filter_state",
+		"1 for the current mode of the MAC filter, 0 for the others",
+		[]string{"state"}, nil)
+	promDescWifiMacFilterTotal = prometheus.NewDesc(
+		metricPrefix+"wifi_mac_filter_total",
+		"number of entries in the MAC filter",
+		[]string{"type"}, nil) // whitelist/blacklist
+	promDescWifiPlanningEnabled = prometheus.NewDesc(
+		metricPrefix+"wifi_planning_enabled",
+		"1 if the Wi-Fi on/off planning is used, 0 if not",
+		nil, nil)
+	promDescWifiPlanningOn = prometheus.NewDesc(
+		metricPrefix+"wifi_planning_on",
+		"1 if the Wi-Fi planning schedules the Wi-Fi on now, 0 if off",
+		nil, nil)
+	promDescWifiPlanningNextChange = prometheus.NewDesc(
+		metricPrefix+"wifi_planning_next_change_timestamp_seconds",
+		"next time the Wi-Fi planning switches the Wi-Fi on/off (unix timestamp)",
+		nil, nil)
+
 	promDescWifiBssInfo = prometheus.NewDesc(
 		metricPrefix+"wifi_bss_info",
 		"constant metric with value=1. Various information about the BSS",
@@ -139,10 +234,54 @@ var (
 		metricPrefix+"wifi_channel",
 		"channel number use by the AP",
 		[]string{"ap_id", "ap_band", "ap_name", "channel_type"}, nil)
+	promDescWifiApConfiguredChannel = prometheus.NewDesc(
+		metricPrefix+"wifi_configured_channel",
+		"channel number configured on the AP (0 if automatic)",
+		[]string{"ap_id", "ap_band", "ap_name", "channel_type"}, nil)
+	promDescWifiApChannelWidth = prometheus.NewDesc(
+		metricPrefix+"wifi_channel_width_mhz",
+		"channel width used by the AP (MHz)",
+		[]string{"ap_id", "ap_band", "ap_name"}, nil)
+	promDescWifiApConfiguredChannelWidth = prometheus.NewDesc(
+		metricPrefix+"wifi_configured_channel_width_mhz",
+		"channel width configured on the AP (MHz)",
+		[]string{"ap_id", "ap_band", "ap_name"}, nil)
+	promDescWifiApState = prometheus.NewDesc(
+		metricPrefix+"wifi_ap_state",
+		"1 for the current state of the AP, 0 for the others",
+		[]string{"ap_id", "ap_band", "ap_name", "state"}, nil)
+	promDescWifiApCapability = prometheus.NewDesc(
+		metricPrefix+"wifi_ap_capability",
+		"1 if the AP supports the capability on this band, 0 if not",
+		[]string{"ap_id", "band", "capability"}, nil)
+	promDescWifiApDfsEnabled = prometheus.NewDesc(
+		metricPrefix+"wifi_ap_dfs_enabled",
+		"1 if DFS is enabled on the AP, 0 if not",
+		[]string{"ap_id", "ap_band", "ap_name"}, nil)
+	promDescWifiApDfsCacRemaining = prometheus.NewDesc(
+		metricPrefix+"wifi_ap_dfs_cac_remaining_seconds",
+		"remaining time of the DFS Channel Availability Check before the AP starts",
+		[]string{"ap_id", "ap_band", "ap_name"}, nil)
 	promDescWifiApStationTotal = prometheus.NewDesc(
 		metricPrefix+"wifi_station_total",
 		"number of stations connected to the AP",
 		[]string{"ap_id", "ap_band", "ap_name"}, nil)
+	promDescWifiApStationSignalHistogram = prometheus.NewDesc(
+		metricPrefix+"wifi_ap_station_signal_dbm",
+		"distribution of the signal of the stations connected to the AP (dBm)",
+		[]string{"ap_id", "ap_band", "ap_name"}, nil)
+	promDescWifiApStationPhyRateHistogram = prometheus.NewDesc(
+		metricPrefix+"wifi_ap_station_phy_rate_bytes",
+		"distribution of the PHY rate of the last packet received/sent by the stations connected to the AP (bytes/s)",
+		[]string{"ap_id", "ap_band", "ap_name", "dir"}, nil) // rx/tx
+	promDescWifiApStationBytesSum = prometheus.NewDesc(
+		metricPrefix+"wifi_ap_station_bytes",
+		"sum of the rx/tx bytes of the stations currently connected to the AP",
+		[]string{"ap_id", "ap_band", "ap_name", "dir"}, nil) // rx/tx
+	promDescWifiApStationStandardTotal = prometheus.NewDesc(
+		metricPrefix+"wifi_ap_station_standard_total",
+		"number of stations connected to the AP by Wi-Fi standard",
+		[]string{"ap_id", "ap_band", "ap_name", "standard"}, nil) // ax/ac/n/legacy
 	promDescWifiApStationInfo = prometheus.NewDesc(
 		metricPrefix+"wifi_station_info",
 		"1 if active, 0 if not",
@@ -156,10 +295,455 @@ var (
 		"signal attenuation in dBm",
 		[]string{"id"}, nil)
 
+	promDescStorageDiskInfo = prometheus.NewDesc(
+		metricPrefix+"storage_disk_info",
+		"constant metric with value=1. Various information about the disk",
+		[]string{"disk_id", "type", "state", "connector", "model", "serial", "firmware"}, nil)
+	promDescStorageDiskTotalBytes = prometheus.NewDesc(
+		metricPrefix+"storage_disk_total_bytes",
+		"size of the disk in bytes",
+		[]string{"disk_id"}, nil)
+	promDescStorageDiskTemp = prometheus.NewDesc(
+		metricPrefix+"storage_disk_temp_degrees",
+		"temperature (°C)",
+		[]string{"disk_id"}, nil)
+	promDescStorageDiskSpinning = prometheus.NewDesc(
+		metricPrefix+"storage_disk_spinning",
+		"1 if the disk is spinning, 0 if not",
+		[]string{"disk_id"}, nil)
+	promDescStorageDiskIdleDuration = prometheus.NewDesc(
+		metricPrefix+"storage_disk_idle_duration_seconds",
+		"time since the last access to the disk",
+		[]string{"disk_id"}, nil)
+	promDescStorageDiskRequests = prometheus.NewDesc(
+		metricPrefix+"storage_disk_requests",
+		"total read/write requests",
+		[]string{"disk_id", "op", "state"}, nil) // read/write, ""/error
+	promDescStorageDiskBytes = prometheus.NewDesc(
+		metricPrefix+"storage_disk_bytes",
+		"total read/written bytes",
+		[]string{"disk_id", "op"}, nil) // read/write
+	promDescStoragePartitionTotalBytes = prometheus.NewDesc(
+		metricPrefix+"storage_partition_total_bytes",
+		"size of the partition in bytes",
+		[]string{"partition_id", "disk_id", "fstype", "label"}, nil)
+	promDescStoragePartitionUsedBytes = prometheus.NewDesc(
+		metricPrefix+"storage_partition_used_bytes",
+		"used space on the partition in bytes",
+		[]string{"partition_id", "disk_id", "fstype", "label"}, nil)
+	promDescStoragePartitionFreeBytes = prometheus.NewDesc(
+		metricPrefix+"storage_partition_free_bytes",
+		"free space on the partition in bytes",
+		[]string{"partition_id", "disk_id", "fstype", "label"}, nil)
+	promDescStorageRaidInfo = prometheus.NewDesc(
+		metricPrefix+"storage_raid_info",
+		"constant metric with value=1. Various information about the RAID array",
+		[]string{"raid_id", "name", "level"}, nil)
+	promDescStorageRaidState = prometheus.NewDesc(
+		metricPrefix+"storage_raid_state",
+		"1 for the current state of the RAID array, 0 for the others",
+		[]string{"raid_id", "name", "state"}, nil)
+	promDescStorageRaidDegraded = prometheus.NewDesc(
+		metricPrefix+"storage_raid_degraded",
+		"1 if the RAID array is degraded, 0 if not",
+		[]string{"raid_id", "name"}, nil)
+	promDescStorageRaidDisks = prometheus.NewDesc(
+		metricPrefix+"storage_raid_disks",
+		"number of disks expected in the RAID array",
+		[]string{"raid_id", "name"}, nil)
+	promDescStorageRaidMemberTotal = prometheus.NewDesc(
+		metricPrefix+"storage_raid_member_total",
+		"number of members of the RAID array by role",
+		[]string{"raid_id", "name", "role"}, nil)
+	promDescStorageRaidSyncCompleted = prometheus.NewDesc(
+		metricPrefix+"storage_raid_sync_completed_ratio",
+		"progress of the current resync/rebuild/check of the RAID array between 0 and 1",
+		[]string{"raid_id", "name", "sync_action"}, nil)
+	promDescStorageRaidMismatch = prometheus.NewDesc(
+		metricPrefix+"storage_raid_mismatch",
+		"number of mismatches found during the last check of the RAID array",
+		[]string{"raid_id", "name"}, nil)
+	promDescDhcpInfo = prometheus.NewDesc(
+		metricPrefix+"dhcp_info",
+		"constant metric with value=1. Various information about the DHCP server",
+		[]string{"enabled", "gateway", "netmask", "ip_range_start", "ip_range_end"}, nil)
+	promDescDhcpPoolSize = prometheus.NewDesc(
+		metricPrefix+"dhcp_pool_size",
+		"number of addresses in the DHCP dynamic range",
+		nil, nil)
+	promDescDhcpDynamicLeaseTotal = prometheus.NewDesc(
+		metricPrefix+"dhcp_dynamic_lease_total",
+		"number of dynamic leases in use",
+		nil, nil)
+	promDescDhcpStaticLeaseTotal = prometheus.NewDesc(
+		metricPrefix+"dhcp_static_lease_total",
+		"number of static leases configured",
+		nil, nil)
+	promDescDhcpPoolUsage = prometheus.NewDesc(
+		metricPrefix+"dhcp_pool_usage_ratio",
+		"ratio of the DHCP dynamic range in use, between 0 and 1",
+		nil, nil)
+	promDescDhcpLeaseRemaining = prometheus.NewDesc(
+		metricPrefix+"dhcp_lease_remaining_seconds",
+		"remaining time of the dynamic lease",
+		[]string{"mac", "hostname", "ip", "static"}, nil)
+	promDescDhcpV6Info = prometheus.NewDesc(
+		metricPrefix+"dhcpv6_info",
+		"constant metric with value=1. Various information about the DHCPv6 server",
+		[]string{"enabled", "use_custom_dns", "dns"}, nil)
+	promDescFwRedirTotal = prometheus.NewDesc(
+		metricPrefix+"fw_redir_total",
+		"number of port forwardings",
+		[]string{"protocol", "enabled"}, nil)
+	promDescFwRedirInfo = prometheus.NewDesc(
+		metricPrefix+"fw_redir_info",
+		"constant metric with value=1. List of the port forwardings",
+		[]string{"id", "protocol", "wan_port", "lan_ip", "lan_port", "src_ip", "comment", "enabled"}, nil)
+	promDescFwIncomingPort = prometheus.NewDesc(
+		metricPrefix+"fw_incoming_port",
+		"port of the service of the Freebox reachable from the WAN",
+		[]string{"id", "type", "enabled", "active"}, nil)
+	promDescFwDmzEnabled = prometheus.NewDesc(
+		metricPrefix+"fw_dmz_enabled",
+		"1 if the DMZ is enabled, 0 if not",
+		[]string{"ip"}, nil)
+	promDescIgdEnabled = prometheus.NewDesc(
+		metricPrefix+"igd_enabled",
+		"1 if UPnP IGD is enabled, 0 if not",
+		nil, nil)
+	promDescIgdRedirTotal = prometheus.NewDesc(
+		metricPrefix+"igd_redir_total",
+		"number of active UPnP redirections",
+		[]string{"protocol"}, nil)
+	promDescIgdRedirInfo = prometheus.NewDesc(
+		metricPrefix+"igd_redir_info",
+		"constant metric with value=1. List of the UPnP redirections",
+		[]string{"id", "protocol", "host", "description", "ext_src_ip", "ext_port", "int_ip", "int_port"}, nil)
+	promDescIgdRedirRemaining = prometheus.NewDesc(
+		metricPrefix+"igd_redir_remaining_seconds",
+		"remaining lease time of the UPnP redirection",
+		[]string{"id"}, nil)
+	promDescIgdRedirNew = prometheus.NewDesc(
+		metricPrefix+"igd_redir_new_total",
+		"number of UPnP redirections created since the exporter started",
+		nil, nil)
+	promDescVpnServerState = prometheus.NewDesc(
+		metricPrefix+"vpn_server_state",
+		"1 for the current state of the VPN server, 0 for the others",
+		[]string{"name", "type", "state"}, nil)
+	promDescVpnServerConnectionTotal = prometheus.NewDesc(
+		metricPrefix+"vpn_server_connection_total",
+		"number of connections to the VPN server",
+		[]string{"name", "type"}, nil)
+	promDescVpnServerAuthConnectionTotal = prometheus.NewDesc(
+		metricPrefix+"vpn_server_authenticated_connection_total",
+		"number of authenticated connections to the VPN server",
+		[]string{"name", "type"}, nil)
+	promDescVpnServerUserTotal = prometheus.NewDesc(
+		metricPrefix+"vpn_server_user_total",
+		"number of users allowed to connect to the VPN servers",
+		nil, nil)
+	promDescVpnServerConnectionBytes = prometheus.NewDesc(
+		metricPrefix+"vpn_server_connection_bytes",
+		"total rx/tx bytes of the VPN connection",
+		[]string{"id", "server", "user", "src_ip", "dir"}, nil) // rx/tx
+	promDescVpnServerConnectionAuthTime = prometheus.NewDesc(
+		metricPrefix+"vpn_server_connection_auth_timestamp_seconds",
+		"time of the authentication of the VPN connection (unix timestamp)",
+		[]string{"id", "server", "user", "src_ip"}, nil)
+	promDescVpnClientConfigInfo = prometheus.NewDesc(
+		metricPrefix+"vpn_client_config_info",
+		"constant metric with value=1. List of the VPN client configurations",
+		[]string{"id", "type", "description", "active"}, nil)
+	promDescVpnClientInfo = prometheus.NewDesc(
+		metricPrefix+"vpn_client_info",
+		"constant metric with value=1. Various information about the VPN client",
+		[]string{"enabled", "active_vpn", "description", "type", "ip", "last_error"}, nil)
+	promDescVpnClientState = prometheus.NewDesc(
+		metricPrefix+"vpn_client_state",
+		"1 for the current state of the VPN client, 0 for the others",
+		[]string{"active_vpn", "type", "state"}, nil)
+	promDescVpnClientUptime = prometheus.NewDesc(
+		metricPrefix+"vpn_client_uptime",
+		"VPN client uptime (in seconds)",
+		[]string{"active_vpn", "type"}, nil)
+	promDescCallTotal = prometheus.NewDesc(
+		metricPrefix+"call_total",
+		"number of calls in the call log",
+		[]string{"type"}, nil) // accepted/missed/outgoing
+	promDescCallDuration = prometheus.NewDesc(
+		metricPrefix+"call_duration_seconds_total",
+		"total duration of the calls in the call log",
+		[]string{"type"}, nil) // accepted/missed/outgoing
+	promDescFreeplugNetworkMemberTotal = prometheus.NewDesc(
+		metricPrefix+"freeplug_network_member_total",
+		"number of Freeplugs in the powerline network",
+		[]string{"net_id"}, nil)
+	promDescFreeplugInfo = prometheus.NewDesc(
+		metricPrefix+"freeplug_info",
+		"constant metric with value=1. Various information about the Freeplug",
+		[]string{"net_id", "id", "model", "net_role", "local"}, nil)
+	promDescFreeplugHasNetwork = prometheus.NewDesc(
+		metricPrefix+"freeplug_has_network",
+		"1 if the Freeplug is connected to the powerline network, 0 if not",
+		[]string{"net_id", "id"}, nil)
+	promDescFreeplugRateBytes = prometheus.NewDesc(
+		metricPrefix+"freeplug_rate_bytes",
+		"PHY rate to the coordinator in bytes/s",
+		[]string{"net_id", "id", "dir"}, nil) // rx/tx
+	promDescFreeplugEthLink = prometheus.NewDesc(
+		metricPrefix+"freeplug_eth_link",
+		"1 if the Ethernet port of the Freeplug is up, 0 if not",
+		[]string{"net_id", "id", "duplex"}, nil)
+	promDescFreeplugEthSpeedBytes = prometheus.NewDesc(
+		metricPrefix+"freeplug_eth_speed_bytes",
+		"speed of the Ethernet port of the Freeplug in bytes/s",
+		[]string{"net_id", "id"}, nil)
+	promDescHomeAdapterInfo = prometheus.NewDesc(
+		metricPrefix+"home_adapter_info",
+		"constant metric with value=1. List of the home automation adapters",
+		[]string{"adapter_id", "label", "status"}, nil)
+	promDescHomeNodeTotal = prometheus.NewDesc(
+		metricPrefix+"home_node_total",
+		"number of home automation nodes by category",
+		[]string{"category"}, nil)
+	promDescHomeNodeInfo = prometheus.NewDesc(
+		metricPrefix+"home_node_info",
+		"constant metric with value=1. List of the home automation nodes",
+		[]string{"node_id", "node_name", "category", "adapter_id", "status"}, nil)
+	promDescHomeNodeBattery = prometheus.NewDesc(
+		metricPrefix+"home_node_battery_percent",
+		"battery level of the node in %",
+		[]string{"node_id", "node_name", "category"}, nil)
+	promDescHomeNodeLastSeen = prometheus.NewDesc(
+		metricPrefix+"home_node_last_seen_timestamp_seconds",
+		"last time the node was seen (unix timestamp)",
+		[]string{"node_id", "node_name", "category"}, nil)
+	promDescHomeNodeValue = prometheus.NewDesc(
+		metricPrefix+"home_node_value",
+		"numeric value of the signal endpoints of the node (bool as 0/1)",
+		[]string{"node_id", "node_name", "category", "endpoint_id", "endpoint", "unit"}, nil)
+	promDescHomeAlarmState = prometheus.NewDesc(
+		metricPrefix+"home_alarm_state",
+		"1 for the current state of the alarm, 0 for the others",
+		[]string{"node_id", "node_name", "state"}, nil)
+	promDescVMHostCPUTotal = prometheus.NewDesc(
+		metricPrefix+"vm_host_cpu_total",
+		"number of CPUs available for the virtual machines",
+		nil, nil)
+	promDescVMHostCPUUsed = prometheus.NewDesc(
+		metricPrefix+"vm_host_cpu_used",
+		"number of CPUs allocated to the running virtual machines",
+		nil, nil)
+	promDescVMHostMemoryTotalBytes = prometheus.NewDesc(
+		metricPrefix+"vm_host_memory_total_bytes",
+		"memory available for the virtual machines in bytes",
+		nil, nil)
+	promDescVMHostMemoryUsedBytes = prometheus.NewDesc(
+		metricPrefix+"vm_host_memory_used_bytes",
+		"memory allocated to the running virtual machines in bytes",
+		nil, nil)
+	promDescVMHostUsbPortTotal = prometheus.NewDesc(
+		metricPrefix+"vm_host_usb_port_total",
+		"number of USB ports available for the virtual machines",
+		nil, nil)
+	promDescVMHostUsbUsed = prometheus.NewDesc(
+		metricPrefix+"vm_host_usb_used",
+		"1 if the USB ports are used by a virtual machine, 0 if not",
+		nil, nil)
+	promDescVMInfo = prometheus.NewDesc(
+		metricPrefix+"vm_info",
+		"constant metric with value=1. Various information about the virtual machine",
+		[]string{"vm_id", "name", "os", "disk_type", "enable_screen", "bind_usb_ports"}, nil)
+	promDescVMStatus = prometheus.NewDesc(
+		metricPrefix+"vm_status",
+		"1 for the current status of the virtual machine, 0 for the others",
+		[]string{"vm_id", "name", "status"}, nil)
+	promDescVMVcpus = prometheus.NewDesc(
+		metricPrefix+"vm_vcpus",
+		"number of virtual CPUs of the virtual machine",
+		[]string{"vm_id", "name"}, nil)
+	promDescVMMemoryBytes = prometheus.NewDesc(
+		metricPrefix+"vm_memory_bytes",
+		"memory of the virtual machine in bytes",
+		[]string{"vm_id", "name"}, nil)
+	promDescDownloadsRateBytes = prometheus.NewDesc(
+		metricPrefix+"downloads_rate_bytes",
+		"current download/upload rate of the download manager in bytes/s",
+		[]string{"dir"}, nil) // rx/tx
+	promDescDownloadsRateLimitBytes = prometheus.NewDesc(
+		metricPrefix+"downloads_rate_limit_bytes",
+		"current download/upload rate limit of the download manager in bytes/s",
+		[]string{"dir"}, nil) // rx/tx
+	promDescDownloadsThrottlingMode = prometheus.NewDesc(
+		metricPrefix+"downloads_throttling_mode",
+		"1 for the current throttling mode of the download manager, 0 for the others",
+		[]string{"mode"}, nil)
+	promDescDownloadsTaskTotal = prometheus.NewDesc(
+		metricPrefix+"downloads_task_total",
+		"number of download tasks by status",
+		[]string{"status"}, nil)
+	promDescDownloadsTaskErrors = prometheus.NewDesc(
+		metricPrefix+"downloads_task_errors_total",
+		"number of download tasks which ended in error since the exporter started",
+		nil, nil)
+	promDescDownloadsTaskSizeBytes = prometheus.NewDesc(
+		metricPrefix+"downloads_task_size_bytes",
+		"size of the download task in bytes",
+		[]string{"id", "name", "type"}, nil)
+	promDescDownloadsTaskProgress = prometheus.NewDesc(
+		metricPrefix+"downloads_task_progress_ratio",
+		"progress of the download task between 0 and 1",
+		[]string{"id", "name", "type"}, nil)
+	promDescDownloadsTaskUploadRatio = prometheus.NewDesc(
+		metricPrefix+"downloads_task_upload_ratio",
+		"uploaded bytes divided by the size of the download task",
+		[]string{"id", "name", "type"}, nil)
+	promDescDownloadsTaskEta = prometheus.NewDesc(
+		metricPrefix+"downloads_task_eta_seconds",
+		"estimated remaining time of the download task",
+		[]string{"id", "name", "type"}, nil)
+	promDescPlayerReachable = prometheus.NewDesc(
+		metricPrefix+"player_reachable",
+		"1 if the player is reachable, 0 if not",
+		[]string{"player_id", "device_name", "device_model"}, nil)
+	promDescPlayerAPIAvailable = prometheus.NewDesc(
+		metricPrefix+"player_api_available",
+		"1 if the API of the player is available, 0 if not",
+		[]string{"player_id", "device_name", "device_model"}, nil)
+	promDescPlayerPowerState = prometheus.NewDesc(
+		metricPrefix+"player_power_state",
+		"1 for the current power state of the player, 0 for the others",
+		[]string{"player_id", "device_name", "device_model", "state"}, nil)
+	promDescPlayerForegroundApp = prometheus.NewDesc(
+		metricPrefix+"player_foreground_app_info",
+		"constant metric with value=1. Application in the foreground of the player",
+		[]string{"player_id", "device_name", "device_model", "package"}, nil)
+	promDescPlayerTvChannel = prometheus.NewDesc(
+		metricPrefix+"player_tv_channel",
+		"number of the TV channel watched on the player",
+		[]string{"player_id", "device_name", "device_model", "channel_name"}, nil)
+	promDescPvrRecordingTotal = prometheus.NewDesc(
+		metricPrefix+"pvr_recording_total",
+		"number of recordings by status",
+		[]string{"status"}, nil) // programmed/running/finished/failed
+	promDescPvrFinishedBytes = prometheus.NewDesc(
+		metricPrefix+"pvr_finished_bytes",
+		"total size of the ended (finished or failed) recordings in bytes",
+		nil, nil)
+	promDescPvrMargin = prometheus.NewDesc(
+		metricPrefix+"pvr_margin_seconds",
+		"margin added before/after the recordings",
+		[]string{"type"}, nil) // before/after
+	promDescPvrFailures = prometheus.NewDesc(
+		metricPrefix+"pvr_failures_total",
+		"number of recordings which ended in a failed state since the exporter started",
+		nil, nil)
+	promDescWifiNeighborTotal = prometheus.NewDesc(
+		metricPrefix+"wifi_neighbor_bss_total",
+		"number of neighbor BSS seen by the AP on the channel",
+		[]string{"ap_id", "band", "channel"}, nil)
+	promDescWifiNeighborSignal = prometheus.NewDesc(
+		metricPrefix+"wifi_neighbor_signal_dbm",
+		"strongest signal of the neighbor BSS seen by the AP on the channel (dBm)",
+		[]string{"ap_id", "band", "channel"}, nil)
+	promDescWifiChannelUsage = prometheus.NewDesc(
+		metricPrefix+"wifi_channel_usage_percent",
+		"usage of the channel measured by the AP in %",
+		[]string{"ap_id", "band", "channel", "type"}, nil) // busy/rx_busy/tx
+	promDescWifiChannelNoise = prometheus.NewDesc(
+		metricPrefix+"wifi_channel_noise_dbm",
+		"noise level of the channel measured by the AP (dBm)",
+		[]string{"ap_id", "band", "channel"}, nil)
+	promDescWifiGuestKeyTotal = prometheus.NewDesc(
+		metricPrefix+"wifi_guest_key_total",
+		"number of guest Wi-Fi keys still valid",
+		nil, nil)
+	promDescWifiGuestKeyRemaining = prometheus.NewDesc(
+		metricPrefix+"wifi_guest_key_remaining_seconds",
+		"remaining validity of the guest Wi-Fi key",
+		[]string{"key_id", "description", "access_type"}, nil)
+	promDescWifiGuestKeyUse = prometheus.NewDesc(
+		metricPrefix+"wifi_guest_key_use_total",
+		"number of devices which used the guest Wi-Fi key",
+		[]string{"key_id", "description", "access_type"}, nil)
+	promDescWifiGuestKeyMaxUse = prometheus.NewDesc(
+		metricPrefix+"wifi_guest_key_max_use",
+		"maximum number of devices allowed to use the guest Wi-Fi key (0 if unlimited)",
+		[]string{"key_id", "description", "access_type"}, nil)
+	promDescWifiGuestKeyStationTotal = prometheus.NewDesc(
+		metricPrefix+"wifi_guest_key_station_total",
+		"number of stations currently connected with a guest Wi-Fi key",
+		nil, nil)
+	promDescRepeaterInfo = prometheus.NewDesc(
+		metricPrefix+"repeater_info",
+		"constant metric with value=1. Various information about the Wi-Fi repeater",
+		[]string{"repeater_id", "name", "model", "serial", "firmware", "connection"}, nil)
+	promDescRepeaterStatus = prometheus.NewDesc(
+		metricPrefix+"repeater_status",
+		"1 for the current status of the Wi-Fi repeater, 0 for the others",
+		[]string{"repeater_id", "name", "status"}, nil)
+	promDescRepeaterUptime = prometheus.NewDesc(
+		metricPrefix+"repeater_uptime",
+		"Wi-Fi repeater uptime (in seconds)",
+		[]string{"repeater_id", "name"}, nil)
+	promDescRepeaterBackhaulSignal = prometheus.NewDesc(
+		metricPrefix+"repeater_backhaul_signal_dbm",
+		"signal of the Wi-Fi link between the repeater and the Freebox in dBm",
+		[]string{"repeater_id", "name", "bssid"}, nil)
+	promDescRepeaterBackhaulRateBytes = prometheus.NewDesc(
+		metricPrefix+"repeater_backhaul_rate_bytes",
+		"current rx/tx rate of the Wi-Fi link between the repeater and the Freebox in bytes/s",
+		[]string{"repeater_id", "name", "bssid", "dir"}, nil) // rx/tx
+	promDescRepeaterBackhaulPhyRateBytes = prometheus.NewDesc(
+		metricPrefix+"repeater_backhaul_phy_rate_bytes",
+		"PHY rate of the last packet received/sent on the Wi-Fi link between the repeater and the Freebox in bytes/s",
+		[]string{"repeater_id", "name", "bssid", "dir"}, nil) // rx/tx
+	promDescRepeaterHostTotal = prometheus.NewDesc(
+		metricPrefix+"repeater_host_total",
+		"number of hosts connected to the Wi-Fi repeater",
+		[]string{"repeater_id", "name", "active"}, nil)
+	promDescRepeaterHostActive = prometheus.NewDesc(
+		metricPrefix+"repeater_host_active",
+		"1 if active, 0 if not. Hosts connected to the Wi-Fi repeater",
+		[]string{"repeater_id", "repeater_name", "vendor_name", "name", "host_type", "l2_id"}, nil)
+	promDescParentalProfileMode = prometheus.NewDesc(
+		metricPrefix+"parental_profile_mode",
+		"1 for the current network access mode of the profile, 0 for the others",
+		[]string{"profile_id", "name", "mode"}, nil)
+	promDescParentalProfileOverride = prometheus.NewDesc(
+		metricPrefix+"parental_profile_override",
+		"1 if a temporary override of the profile planning is active, 0 if not",
+		[]string{"profile_id", "name"}, nil)
+	promDescParentalProfileOverrideEnd = prometheus.NewDesc(
+		metricPrefix+"parental_profile_override_end_timestamp_seconds",
+		"end of the temporary override of the profile planning (unix timestamp)",
+		[]string{"profile_id", "name"}, nil)
+	promDescParentalProfileHostTotal = prometheus.NewDesc(
+		metricPrefix+"parental_profile_host_total",
+		"number of hosts attached to the profile",
+		[]string{"profile_id", "name"}, nil)
+
+	// buckets of the Wi-Fi histograms
+	wifiSignalBuckets  = []float64{-90, -80, -70, -60, -50, -40, -30}
+	wifiPhyRateBuckets = []float64{6e6 / 8, 24e6 / 8, 54e6 / 8, 150e6 / 8, 300e6 / 8, 600e6 / 8, 1200e6 / 8, 2400e6 / 8, 4800e6 / 8}
+
 	promDescLanHostTotal = prometheus.NewDesc(
 		metricPrefix+"lan_host_total",
 		"number of hosts detected",
 		[]string{"interface", "active"}, nil)
+	promDescLanHostTypeTotal = prometheus.NewDesc(
+		metricPrefix+"lan_host_type_total",
+		"number of active hosts by type",
+		[]string{"interface", "host_type"}, nil)
+	promDescLanHostVendorTotal = prometheus.NewDesc(
+		metricPrefix+"lan_host_vendor_total",
+		"number of active hosts by vendor. Only the most common vendors are listed, the others are in \"other\"",
+		[]string{"interface", "vendor_name"}, nil)
+	promDescLanHostL3TypeTotal = prometheus.NewDesc(
+		metricPrefix+"lan_host_l3_type_total",
+		"number of active hosts having an active address of this type",
+		[]string{"interface", "l3_type"}, nil)
 	promDescLanHostActiveL2 = prometheus.NewDesc(
 		metricPrefix+"lan_host_active_l2",
 		"1 if active, 0 if not. Various information about the l2 addresses",
@@ -168,14 +752,102 @@ var (
 		metricPrefix+"lan_host_active_l3",
 		"1 if active, 0 if not. Various information about the l3 addresses",
 		[]string{"interface", "vendor_name", "primary_name", "host_type", "l2_type", "l2_id", "l3_type", "l3_address"}, nil)
+	promDescLanHostReachable = prometheus.NewDesc(
+		metricPrefix+"lan_host_reachable",
+		"1 if reachable, 0 if not",
+		[]string{"interface", "name", "l2_id"}, nil)
+	promDescLanHostLastActivity = prometheus.NewDesc(
+		metricPrefix+"lan_host_last_activity_timestamp_seconds",
+		"last time the host sent traffic (unix timestamp)",
+		[]string{"interface", "name", "l2_id"}, nil)
+	promDescLanHostLastTimeReachable = prometheus.NewDesc(
+		metricPrefix+"lan_host_last_reachable_timestamp_seconds",
+		"last time the host was reachable (unix timestamp)",
+		[]string{"interface", "name", "l2_id"}, nil)
+	promDescLanHostL3LastActivity = prometheus.NewDesc(
+		metricPrefix+"lan_host_l3_last_activity_timestamp_seconds",
+		"last time the l3 address sent traffic (unix timestamp)",
+		[]string{"interface", "name", "l2_id", "l3_type", "l3_address"}, nil)
+	promDescLanHostL3LastTimeReachable = prometheus.NewDesc(
+		metricPrefix+"lan_host_l3_last_reachable_timestamp_seconds",
+		"last time the l3 address was reachable (unix timestamp)",
+		[]string{"interface", "name", "l2_id", "l3_type", "l3_address"}, nil)
 )
 
+// hostNameSources are the values accepted in a hostNamePolicy.
+// "primary" is the name displayed by the Freebox, the others are the sources of MetricsFreeboxLanHost.Names
+var hostNameSources = []string{"primary", "dhcp", "netbios", "mdns", "mdns_srv", "upnp", "wsd"}
+
+// hostNamePolicy is the list of the sources used to name the LAN hosts, by order of preference
+type hostNamePolicy []string
+
+func parseHostNamePolicy(s string) (hostNamePolicy, error) {
+	result := hostNamePolicy{}
+	for _, source := range strings.Split(s, ",") {
+		source = strings.TrimSpace(source)
+		if source == "" {
+			continue
+		}
+		known := false
+		for _, v := range hostNameSources {
+			if v == source {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown host name source \"%s\" (expected one of %s)", source, strings.Join(hostNameSources, ", "))
+		}
+		result = append(result, source)
+	}
+	return result, nil
+}
+
+// resolve returns the name given by the first source of the policy which knows the host.
+// Defaults to the primary name
+func (p hostNamePolicy) resolve(host *fbx.MetricsFreeboxLanHost) string {
+	for _, source := range p {
+		if source == "primary" {
+			if host.PrimaryName != "" {
+				return host.PrimaryName
+			}
+			continue
+		}
+		for _, name := range host.Names {
+			if name != nil && name.Source == source && name.Name != "" {
+				return name.Name
+			}
+		}
+	}
+	return host.PrimaryName
+}
+
 // Collector is the prometheus collector for the freebox exporter
 type Collector struct {
 	hostDetails       bool
+	hostNamePolicy    hostNamePolicy
+	location          *time.Location
 	freeboxApiVersion string
 	url               string
 	freebox           *fbx.FreeboxClientV5
+
+	// state kept between 2 scrapes
+	stateLock  sync.Mutex
+	lastUptime int64
+	reboots    uint64
+	igdRedirs  map[string]bool
+	igdNew     uint64
+	callsSeen  bool
+	lastCallID int64
+	calls      map[string]uint64
+	callsTime  map[string]int64
+
+	downloadStatus map[int64]string
+	downloadErrors uint64
+	pvrFailed      map[int64]bool
+	pvrFailures    uint64
+
+	missingRights map[string]bool
 }
 
 func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
@@ -193,7 +865,6 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	log.Debug.Println("Collect")
 	wg := sync.WaitGroup{}
-	wg.Add(5)
 
 	getMetricSuccessful := true
 	var firmwareVersion string
@@ -201,6 +872,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	var serial string
 	var boardName string
 	var boxFlavor string
+	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		log.Debug.Println("Collect system")
@@ -213,19 +885,28 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 			boxFlavor = m.BoxFlavor
 
 			c.collectCounter(ch, m.UptimeValue, promDescSystemUptime)
+			if m.UptimeValue != nil {
+				ch <- prometheus.MustNewConstMetric(promDescSystemReboots, prometheus.CounterValue, float64(c.countReboots(*m.UptimeValue)))
+			}
+			if m.BoxAuthenticated != nil {
+				c.collectStateSet(ch, promDescSystemAuthenticated, c.toString(m.BoxAuthenticated), []string{"true", "false"})
+			}
+			c.collectStateSet(ch, promDescSystemDiskStatus, m.DiskStatus,
+				[]string{"not_detected", "disabled", "initializing", "error", "active"},
+				m.UserMainStorage)
 			for _, sensor := range m.Sensors {
-				c.collectGauge(ch, sensor.Value, promDescSystemTemp, sensor.ID)
+				c.collectGauge(ch, sensor.Value, promDescSystemTemp, sensor.ID, sensor.Name)
 			}
 			if len(m.Sensors) == 0 {
-				c.collectGauge(ch, m.TempCPUM, promDescSystemTemp, "temp_cpum")
-				c.collectGauge(ch, m.TempCPUB, promDescSystemTemp, "temp_cpub")
-				c.collectGauge(ch, m.TempSW, promDescSystemTemp, "temp_sw")
+				c.collectGauge(ch, m.TempCPUM, promDescSystemTemp, "temp_cpum", "")
+				c.collectGauge(ch, m.TempCPUB, promDescSystemTemp, "temp_cpub", "")
+				c.collectGauge(ch, m.TempSW, promDescSystemTemp, "temp_sw", "")
 			}
 			for _, fan := range m.Fans {
-				c.collectGauge(ch, fan.Value, promDescSystemFanRpm, fan.ID)
+				c.collectGauge(ch, fan.Value, promDescSystemFanRpm, fan.ID, fan.Name)
 			}
 			if len(m.Fans) == 0 {
-				c.collectGauge(ch, m.FanRpm, promDescSystemFanRpm, "fan")
+				c.collectGauge(ch, m.FanRpm, promDescSystemFanRpm, "fan", "")
 			}
 		} else {
 			getMetricSuccessful = false
@@ -238,6 +919,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	var cnxMedia string
 	var cnxIPv4 string
 	var cnxIPv6 string
+	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		log.Debug.Println("Collect connection")
@@ -297,234 +979,1128 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 					m.Ftth.SfpVendor,
 					"rx")
 			}
+			if m.Lte != nil {
+				c.collectBool(ch, m.Lte.Enabled, promDescConnectionLteEnabled)
+				c.collectStateSet(ch, promDescConnectionLteState, m.Lte.State,
+					[]string{"disabled", "starting", "connecting", "connected", "error"})
+				if m.Lte.Radio != nil {
+					for _, band := range m.Lte.Radio.Bands {
+						if !c.toBool(band.Enabled) {
+							continue
+						}
+						bandID := c.toString(band.Band)
+						c.collectGauge(ch, band.Rsrp, promDescConnectionLteRsrp, bandID)
+						c.collectGauge(ch, band.Rsrq, promDescConnectionLteRsrq, bandID)
+						c.collectGauge(ch, band.Rssi, promDescConnectionLteRssi, bandID)
+						c.collectGauge(ch, band.Sinr, promDescConnectionLteSinr, bandID)
+					}
+				}
+			}
+			if m.Aggregation != nil {
+				c.collectAggregation(ch, m.Aggregation)
+			}
 		} else {
 			getMetricSuccessful = false
 			log.Error.Println(err)
 		}
 	}()
 
-	go func() {
-		defer wg.Done()
-		log.Debug.Println("Collect switch")
+	collectors := []func(chan<- prometheus.Metric) error{
+		c.collectSwitch,
+		c.collectWifi,
+		c.collectLan,
+		c.collectStorage,
+		c.collectStorageRaid,
+		c.collectDhcp,
+		c.collectFirewall,
+		c.collectIgd,
+		c.collectVpnServer,
+		c.collectVpnClient,
+		c.collectCalls,
+		c.collectFreeplug,
+		c.collectHome,
+		c.collectVM,
+		c.collectDownloads,
+		c.collectPlayer,
+		c.collectPvr,
+		c.collectWifiGuestKeys,
+		c.collectRepeater,
+		c.collectParental,
+	}
+	for _, collect := range collectors {
+		wg.Add(1)
+		go func(collect func(chan<- prometheus.Metric) error) {
+			defer wg.Done()
+			if err := collect(ch); err != nil {
+				getMetricSuccessful = false
+				log.Error.Println(err)
+			}
+		}(collect)
+	}
 
-		if m, err := c.freebox.GetMetricsSwitch(); err == nil {
-			numPortsConnected := 0
+	wg.Wait()
 
-			for _, port := range m.Ports {
-				if port.Link == "up" {
-					numPortsConnected++
-				}
+	ch <- prometheus.MustNewConstMetric(promDescExporterInfo, prometheus.GaugeValue, c.toFloat(getMetricSuccessful),
+		c.url,
+		c.freeboxApiVersion)
 
-				speed, _ := strconv.Atoi(port.Speed)
-				portID := c.toString(port.ID)
-				ch <- prometheus.MustNewConstMetric(promDescSwitchPortBandwidthBytes, prometheus.GaugeValue, float64(speed)/8,
-					portID,
-					port.Link,
-					port.Duplex)
-				if port.Stats != nil {
-					c.collectCounter(ch, port.Stats.RxGoodBytes, promDescSwitchPortBytes, portID, "rx", "good")
-					c.collectCounter(ch, port.Stats.RxBadBytes, promDescSwitchPortBytes, portID, "rx", "bad")
-					c.collectCounter(ch, port.Stats.TxBytes, promDescSwitchPortBytes, portID, "tx", "")
-
-					c.collectCounter(ch, port.Stats.RxGoodPackets, promDescSwitchPortPackets, portID, "rx", "good")
-					c.collectCounter(ch, port.Stats.RxBroadcastPackets, promDescSwitchPortPackets, portID, "rx", "bad")
-					c.collectCounter(ch, port.Stats.TxBroadcastPackets, promDescSwitchPortPackets, portID, "tx", "")
-				}
+	ch <- prometheus.MustNewConstMetric(promDescInfo, prometheus.GaugeValue, 1,
+		firmwareVersion,
+		mac,
+		serial,
+		boardName,
+		boxFlavor,
+		cnxType,
+		cnxState,
+		cnxMedia,
+		cnxIPv4,
+		cnxIPv6)
+}
 
-				ch <- prometheus.MustNewConstMetric(promDescSwitchHostTotal, prometheus.GaugeValue, float64(len(port.MacList)), portID)
-				if c.hostDetails {
-					for _, mac := range port.MacList {
-						ch <- prometheus.MustNewConstMetric(promDescSwitchHost, prometheus.GaugeValue, 1,
-							portID,
-							strings.ToLower(mac.Mac),
-							mac.Hostname)
-					}
-				}
+func (c *Collector) collectSwitch(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect switch")
+
+	if m, err := c.freebox.GetMetricsSwitch(); err == nil {
+		numPortsConnected := 0
+
+		for _, port := range m.Ports {
+			if port.Link == "up" {
+				numPortsConnected++
 			}
 
-			ch <- prometheus.MustNewConstMetric(promDescSwitchPortConnectedTotal, prometheus.GaugeValue, float64(numPortsConnected))
-		} else {
-			getMetricSuccessful = false
-			log.Error.Println(err)
+			speed, _ := strconv.Atoi(port.Speed)
+			portID := c.toString(port.ID)
+			ch <- prometheus.MustNewConstMetric(promDescSwitchPortBandwidthBytes, prometheus.GaugeValue, float64(speed)/8,
+				portID,
+				port.Link,
+				port.Duplex)
+			if port.Stats != nil {
+				c.collectCounter(ch, port.Stats.RxGoodBytes, promDescSwitchPortBytes, portID, "rx", "good")
+				c.collectCounter(ch, port.Stats.RxBadBytes, promDescSwitchPortBytes, portID, "rx", "bad")
+				c.collectCounter(ch, port.Stats.TxBytes, promDescSwitchPortBytes, portID, "tx", "")
+
+				c.collectCounter(ch, port.Stats.RxGoodPackets, promDescSwitchPortPackets, portID, "rx", "good")
+				c.collectCounter(ch, port.Stats.RxBroadcastPackets, promDescSwitchPortPackets, portID, "rx", "bad")
+				c.collectCounter(ch, port.Stats.TxBroadcastPackets, promDescSwitchPortPackets, portID, "tx", "")
+			}
+
+			ch <- prometheus.MustNewConstMetric(promDescSwitchHostTotal, prometheus.GaugeValue, float64(len(port.MacList)), portID)
+			if c.hostDetails {
+				for _, mac := range port.MacList {
+					ch <- prometheus.MustNewConstMetric(promDescSwitchHost, prometheus.GaugeValue, 1,
+						portID,
+						strings.ToLower(mac.Mac),
+						mac.Hostname)
+				}
+			}
 		}
-	}()
 
-	go func() {
-		defer wg.Done()
-		log.Debug.Println("Collect wifi")
-		if m, err := c.freebox.GetMetricsWifi(); err == nil {
-
-			for _, bss := range m.Bss {
-				phyID := strconv.FormatInt(bss.PhyID, 10)
-				bssid := strings.ToLower(bss.ID)
-
-				ch <- prometheus.MustNewConstMetric(promDescWifiBssInfo, prometheus.GaugeValue, 1,
-					bssid,
-					phyID,
-					bss.Status.State,
-					c.toString(bss.Config.Enabled),
-					bss.Config.Ssid,
-					c.toString(bss.Config.HideSsid),
-					bss.Config.Encryption,
-					c.toString(bss.Config.EapolVersion))
-				c.collectGauge(ch, bss.Status.StaCount, promDescWifiBssStationTotal, bssid, phyID)
-				c.collectGauge(ch, bss.Status.AuthorizedStaCount, promDescWifiBssAuthorizedStationTotal, bssid, phyID)
-			}
-
-			for _, ap := range m.Ap {
-				apID := strconv.FormatInt(ap.ID, 10)
-
-				if capabilities, ok := ap.Capabilities[ap.Config.Band]; ok {
-					labels := prometheus.Labels{}
-					labels["ap_id"] = apID
-					labels["ap_band"] = ap.Config.Band
-					labels["ap_name"] = ap.Name
-					labels["ap_state"] = ap.Status.State
-
-					for k, v := range capabilities {
-						labels[k] = c.toString(v)
-					}
+		ch <- prometheus.MustNewConstMetric(promDescSwitchPortConnectedTotal, prometheus.GaugeValue, float64(numPortsConnected))
+	} else {
+		return err
+	}
+	return nil
+}
 
-					promDescWifiApInfo := prometheus.NewDesc(
-						metricPrefix+"wifi_ap_info",
-						"constant metric with value=1. List of AP capabilities",
-						nil, labels)
-					ch <- prometheus.MustNewConstMetric(promDescWifiApInfo, prometheus.GaugeValue, 1)
+func (c *Collector) collectWifi(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect wifi")
+	if m, err := c.freebox.GetMetricsWifi(); err == nil {
+		if m.Config != nil {
+			c.collectBool(ch, m.Config.Enabled, promDescWifiEnabled)
+			c.collectBool(ch, m.Config.PowerSaving, promDescWifiPowerSaving)
+			c.collectStateSet(ch, promDescWifiMacFilterState, m.Config.MacFilterState,
+				[]string{"disabled", "whitelist", "blacklist"})
+		}
+		if m.Planning != nil {
+			c.collectBool(ch, m.Planning.UsePlanning, promDescWifiPlanningEnabled)
+			if c.toBool(m.Planning.UsePlanning) {
+				c.collectWifiPlanning(ch, m.Planning.Mapping, time.Now().In(c.location))
+			}
+		}
+		macFilters := map[string]int{
+			"whitelist": 0,
+			"blacklist": 0,
+		}
+		for _, filter := range m.MacFilters {
+			macFilters[filter.Type]++
+		}
+		for filterType, count := range macFilters {
+			ch <- prometheus.MustNewConstMetric(promDescWifiMacFilterTotal, prometheus.GaugeValue, float64(count), filterType)
+		}
 
-				}
+		for _, bss := range m.Bss {
+			phyID := strconv.FormatInt(bss.PhyID, 10)
+			bssid := strings.ToLower(bss.ID)
 
-				c.collectGauge(ch, ap.Status.PrimaryChannel, promDescWifiApChannel,
-					apID,
-					ap.Config.Band,
-					ap.Name,
-					"primary")
-				c.collectGauge(ch, ap.Status.SecondaryChannel, promDescWifiApChannel,
-					apID,
-					ap.Config.Band,
-					ap.Name,
-					"secondary")
-				ch <- prometheus.MustNewConstMetric(promDescWifiApStationTotal, prometheus.GaugeValue, float64(len(ap.Stations)),
-					apID,
-					ap.Config.Band,
-					ap.Name)
-				if c.hostDetails {
-					for _, station := range ap.Stations {
-						stationActive := c.toFloat(station.Host != nil && c.toBool(station.Host.Active))
-						bssid := strings.ToLower(station.Bssid)
-						mac := strings.ToLower(station.Mac)
-						stationID := strings.ToLower(station.ID)
-						ssid := ""
-						encryption := ""
-						if station.Bss != nil {
-							ssid = station.Bss.Config.Ssid
-							encryption = station.Bss.Config.Encryption
-						}
+			ch <- prometheus.MustNewConstMetric(promDescWifiBssInfo, prometheus.GaugeValue, 1,
+				bssid,
+				phyID,
+				bss.Status.State,
+				c.toString(bss.Config.Enabled),
+				bss.Config.Ssid,
+				c.toString(bss.Config.HideSsid),
+				bss.Config.Encryption,
+				c.toString(bss.Config.EapolVersion))
+			c.collectGauge(ch, bss.Status.StaCount, promDescWifiBssStationTotal, bssid, phyID)
+			c.collectGauge(ch, bss.Status.AuthorizedStaCount, promDescWifiBssAuthorizedStationTotal, bssid, phyID)
+		}
 
-						ch <- prometheus.MustNewConstMetric(promDescWifiApStationInfo, prometheus.GaugeValue, stationActive,
-							apID,
-							ap.Config.Band,
-							ap.Name,
-							stationID,
-							bssid,
-							ssid,
-							encryption,
-							station.Hostname,
-							mac)
-						c.collectCounter(ch, station.RxBytes, promDescWifiApStationBytes,
-							stationID,
-							"rx",
-						)
-						c.collectCounter(ch, station.TxBytes, promDescWifiApStationBytes,
-							stationID,
-							"tx",
-						)
-						c.collectGauge(ch, station.Signal, promDescWifiApStationSignalDbm,
-							stationID)
+		for _, ap := range m.Ap {
+			apID := strconv.FormatInt(ap.ID, 10)
+
+			c.collectStateSet(ch, promDescWifiApState, ap.Status.State,
+				[]string{"scanning", "no_param", "bad_param", "disabled", "disabled_planning", "no_active_bss", "starting", "acs", "ht_scan", "dfs", "active", "failed"},
+				apID,
+				ap.Config.Band,
+				ap.Name)
+			for band, capabilities := range ap.Capabilities {
+				for capability, v := range capabilities {
+					ch <- prometheus.MustNewConstMetric(promDescWifiApCapability, prometheus.GaugeValue, c.toFloat(v),
+						apID,
+						band,
+						capability)
+				}
+			}
+			c.collectBool(ch, ap.Config.DfsEnabled, promDescWifiApDfsEnabled,
+				apID,
+				ap.Config.Band,
+				ap.Name)
+			c.collectGauge(ch, ap.Status.DfsCacRemainingTime, promDescWifiApDfsCacRemaining,
+				apID,
+				ap.Config.Band,
+				ap.Name)
+			c.collectChannelWidth(ch, ap.Status.ChannelWidth, promDescWifiApChannelWidth,
+				apID,
+				ap.Config.Band,
+				ap.Name)
+			c.collectChannelWidth(ch, ap.Config.ChannelWidth, promDescWifiApConfiguredChannelWidth,
+				apID,
+				ap.Config.Band,
+				ap.Name)
+			c.collectGauge(ch, ap.Config.PrimaryChannel, promDescWifiApConfiguredChannel,
+				apID,
+				ap.Config.Band,
+				ap.Name,
+				"primary")
+			c.collectGauge(ch, ap.Config.SecondaryChannel, promDescWifiApConfiguredChannel,
+				apID,
+				ap.Config.Band,
+				ap.Name,
+				"secondary")
+			c.collectGauge(ch, ap.Status.PrimaryChannel, promDescWifiApChannel,
+				apID,
+				ap.Config.Band,
+				ap.Name,
+				"primary")
+			c.collectGauge(ch, ap.Status.SecondaryChannel, promDescWifiApChannel,
+				apID,
+				ap.Config.Band,
+				ap.Name,
+				"secondary")
+			ch <- prometheus.MustNewConstMetric(promDescWifiApStationTotal, prometheus.GaugeValue, float64(len(ap.Stations)),
+				apID,
+				ap.Config.Band,
+				ap.Name)
+			c.collectWifiStationAggregates(ch, ap, apID)
+			c.collectWifiNeighbors(ch, ap, apID)
+			if c.hostDetails {
+				for _, station := range ap.Stations {
+					stationActive := c.toFloat(station.Host != nil && c.toBool(station.Host.Active))
+					bssid := strings.ToLower(station.Bssid)
+					mac := strings.ToLower(station.Mac)
+					stationID := strings.ToLower(station.ID)
+					ssid := ""
+					encryption := ""
+					if station.Bss != nil {
+						ssid = station.Bss.Config.Ssid
+						encryption = station.Bss.Config.Encryption
 					}
+
+					ch <- prometheus.MustNewConstMetric(promDescWifiApStationInfo, prometheus.GaugeValue, stationActive,
+						apID,
+						ap.Config.Band,
+						ap.Name,
+						stationID,
+						bssid,
+						ssid,
+						encryption,
+						station.Hostname,
+						mac)
+					c.collectCounter(ch, station.RxBytes, promDescWifiApStationBytes,
+						stationID,
+						"rx",
+					)
+					c.collectCounter(ch, station.TxBytes, promDescWifiApStationBytes,
+						stationID,
+						"tx",
+					)
+					c.collectGauge(ch, station.Signal, promDescWifiApStationSignalDbm,
+						stationID)
 				}
 			}
-		} else {
-			getMetricSuccessful = false
-			log.Error.Println(err)
 		}
+	} else {
+		return err
+	}
 
-	}()
+	return nil
+}
 
-	go func() {
-		defer wg.Done()
-		log.Debug.Println("Collect lan")
-
-		if m, err := c.freebox.GetMetricsLan(); err == nil {
-			for name, hosts := range m.Hosts {
-				hostsUnknown := 0
-				hostsActive := 0
-				hostsInactive := 0
-
-				for _, host := range hosts {
-					if host != nil {
-						active := c.toBool(host.Active)
-						if active {
-							hostsActive++
-						} else {
-							hostsInactive++
+func (c *Collector) collectLan(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect lan")
+
+	if m, err := c.freebox.GetMetricsLan(); err == nil {
+		for name, hosts := range m.Hosts {
+			hostsUnknown := 0
+			hostsActive := 0
+			hostsInactive := 0
+			activeByType := map[string]int{}
+			activeByVendor := map[string]int{}
+			activeByAf := map[string]int{}
+
+			for _, host := range hosts {
+				if host != nil {
+					active := c.toBool(host.Active)
+					if active {
+						hostsActive++
+						activeByType[host.HostType]++
+						activeByVendor[host.VendorName]++
+
+						afs := map[string]bool{}
+						for _, l3 := range host.L3Connectivities {
+							if c.toBool(l3.Active) {
+								afs[l3.Af] = true
+							}
 						}
+						for af := range afs {
+							activeByAf[af]++
+						}
+					} else {
+						hostsInactive++
+					}
+
+					if c.hostDetails {
+						hostName := c.hostNamePolicy.resolve(host)
+						l2ID := strings.ToLower(host.L2Ident.ID)
 
-						if c.hostDetails {
-							ch <- prometheus.MustNewConstMetric(promDescLanHostActiveL2, prometheus.GaugeValue, c.toFloat(active),
+						ch <- prometheus.MustNewConstMetric(promDescLanHostActiveL2, prometheus.GaugeValue, c.toFloat(active),
+							name,
+							host.VendorName,
+							hostName,
+							host.HostType,
+							host.L2Ident.Type,
+							l2ID)
+						c.collectBool(ch, host.Reachable, promDescLanHostReachable, name, hostName, l2ID)
+						c.collectGauge(ch, host.LastActivity, promDescLanHostLastActivity, name, hostName, l2ID)
+						c.collectGauge(ch, host.LastTimeReachable, promDescLanHostLastTimeReachable, name, hostName, l2ID)
+
+						for _, l3 := range host.L3Connectivities {
+							ch <- prometheus.MustNewConstMetric(promDescLanHostActiveL3, prometheus.GaugeValue, c.toFloat(c.toBool(l3.Active)),
 								name,
 								host.VendorName,
-								host.PrimaryName,
+								hostName,
 								host.HostType,
 								host.L2Ident.Type,
-								strings.ToLower(host.L2Ident.ID))
-
-							for _, l3 := range host.L3Connectivities {
-								ch <- prometheus.MustNewConstMetric(promDescLanHostActiveL3, prometheus.GaugeValue, c.toFloat(c.toBool(l3.Active)),
-									name,
-									host.VendorName,
-									host.PrimaryName,
-									host.HostType,
-									host.L2Ident.Type,
-									strings.ToLower(host.L2Ident.ID),
-									l3.Af,
-									l3.Addr)
-							}
+								l2ID,
+								l3.Af,
+								l3.Addr)
+							c.collectGauge(ch, l3.LastActivity, promDescLanHostL3LastActivity, name, hostName, l2ID, l3.Af, l3.Addr)
+							c.collectGauge(ch, l3.LastTimeReachable, promDescLanHostL3LastTimeReachable, name, hostName, l2ID, l3.Af, l3.Addr)
 						}
-					} else {
-						hostsUnknown += len(hosts)
 					}
+				} else {
+					hostsUnknown += len(hosts)
 				}
+			}
+
+			ch <- prometheus.MustNewConstMetric(promDescLanHostTotal, prometheus.GaugeValue, float64(hostsActive), name, "true")
+			ch <- prometheus.MustNewConstMetric(promDescLanHostTotal, prometheus.GaugeValue, float64(hostsInactive), name, "false")
+			if hostsUnknown > 0 {
+				// there should not be any
+				ch <- prometheus.MustNewConstMetric(promDescLanHostTotal, prometheus.GaugeValue, float64(hostsUnknown), name, "unknown")
+			}
+			for hostType, count := range activeByType {
+				ch <- prometheus.MustNewConstMetric(promDescLanHostTypeTotal, prometheus.GaugeValue, float64(count), name, hostType)
+			}
+			for vendorName, count := range c.topN(activeByVendor, lanHostTopVendors, "other") {
+				ch <- prometheus.MustNewConstMetric(promDescLanHostVendorTotal, prometheus.GaugeValue, float64(count), name, vendorName)
+			}
+			for af, count := range activeByAf {
+				ch <- prometheus.MustNewConstMetric(promDescLanHostL3TypeTotal, prometheus.GaugeValue, float64(count), name, af)
+			}
+		}
+	} else {
+		return err
+	}
+	return nil
+}
 
-				ch <- prometheus.MustNewConstMetric(promDescLanHostTotal, prometheus.GaugeValue, float64(hostsActive), name, "true")
-				ch <- prometheus.MustNewConstMetric(promDescLanHostTotal, prometheus.GaugeValue, float64(hostsInactive), name, "false")
-				if hostsUnknown > 0 {
-					// there should not be any
-					ch <- prometheus.MustNewConstMetric(promDescLanHostTotal, prometheus.GaugeValue, float64(hostsUnknown), name, "unknown")
+func (c *Collector) collectStorage(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect storage")
+
+	if m, err := c.freebox.GetMetricsStorage(); err == nil {
+		for _, disk := range m.Disks {
+			diskID := strconv.FormatInt(disk.ID, 10)
+
+			ch <- prometheus.MustNewConstMetric(promDescStorageDiskInfo, prometheus.GaugeValue, 1,
+				diskID,
+				disk.Type,
+				disk.State,
+				c.toString(disk.Connector),
+				disk.Model,
+				disk.Serial,
+				disk.Firmware)
+			c.collectGauge(ch, disk.TotalBytes, promDescStorageDiskTotalBytes, diskID)
+			c.collectGauge(ch, disk.Temp, promDescStorageDiskTemp, diskID)
+			c.collectBool(ch, disk.Spinning, promDescStorageDiskSpinning, diskID)
+			c.collectGauge(ch, disk.IdleDuration, promDescStorageDiskIdleDuration, diskID)
+			c.collectCounter(ch, disk.ReadRequests, promDescStorageDiskRequests, diskID, "read", "")
+			c.collectCounter(ch, disk.ReadErrorRequests, promDescStorageDiskRequests, diskID, "read", "error")
+			c.collectCounter(ch, disk.WriteRequests, promDescStorageDiskRequests, diskID, "write", "")
+			c.collectCounter(ch, disk.WriteErrorRequests, promDescStorageDiskRequests, diskID, "write", "error")
+			c.collectCounter(ch, disk.ReadBytes, promDescStorageDiskBytes, diskID, "read")
+			c.collectCounter(ch, disk.WriteBytes, promDescStorageDiskBytes, diskID, "write")
+		}
+
+		for _, partition := range m.Partitions {
+			partitionID := strconv.FormatInt(partition.ID, 10)
+			diskID := strconv.FormatInt(partition.DiskID, 10)
+
+			c.collectGauge(ch, partition.TotalBytes, promDescStoragePartitionTotalBytes, partitionID, diskID, partition.FsType, partition.Label)
+			c.collectGauge(ch, partition.UsedBytes, promDescStoragePartitionUsedBytes, partitionID, diskID, partition.FsType, partition.Label)
+			c.collectGauge(ch, partition.FreeBytes, promDescStoragePartitionFreeBytes, partitionID, diskID, partition.FsType, partition.Label)
+		}
+	} else {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectStorageRaid(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect RAID")
+
+	if m, err := c.freebox.GetMetricsStorageRaid(); err == nil {
+		for _, raid := range m {
+			raidID := strconv.FormatInt(raid.ID, 10)
+
+			ch <- prometheus.MustNewConstMetric(promDescStorageRaidInfo, prometheus.GaugeValue, 1,
+				raidID,
+				raid.Name,
+				raid.Level)
+			c.collectStateSet(ch, promDescStorageRaidState, raid.State,
+				[]string{"stopped", "running", "error"},
+				raidID,
+				raid.Name)
+			c.collectBool(ch, raid.Degraded, promDescStorageRaidDegraded, raidID, raid.Name)
+			c.collectGauge(ch, raid.RaidDisks, promDescStorageRaidDisks, raidID, raid.Name)
+			c.collectGauge(ch, raid.MismatchCount, promDescStorageRaidMismatch, raidID, raid.Name)
+
+			members := map[string]int{
+				"active": 0,
+				"spare":  0,
+				"faulty": 0,
+			}
+			for _, member := range raid.Members {
+				members[member.Role]++
+			}
+			for role, count := range members {
+				ch <- prometheus.MustNewConstMetric(promDescStorageRaidMemberTotal, prometheus.GaugeValue, float64(count),
+					raidID,
+					raid.Name,
+					role)
+			}
+
+			if raid.SyncCompletedEnd != nil && raid.SyncCompletedPos != nil && *raid.SyncCompletedEnd > 0 {
+				ch <- prometheus.MustNewConstMetric(promDescStorageRaidSyncCompleted, prometheus.GaugeValue, float64(*raid.SyncCompletedPos)/float64(*raid.SyncCompletedEnd),
+					raidID,
+					raid.Name,
+					raid.SyncAction)
+			} else {
+				c.collectGaugeWithFactor(ch, raid.SyncCompletedPercent, 0.01, promDescStorageRaidSyncCompleted,
+					raidID,
+					raid.Name,
+					raid.SyncAction)
+			}
+		}
+	} else if c.isUnsupported(err) {
+		// the RAID is only available on some models (Freebox Delta)
+	} else {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectDhcp(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect DHCP")
+
+	if m, err := c.freebox.GetMetricsDhcp(); err == nil {
+		ch <- prometheus.MustNewConstMetric(promDescDhcpInfo, prometheus.GaugeValue, 1,
+			c.toString(m.Config.Enabled),
+			m.Config.Gateway,
+			m.Config.Netmask,
+			m.Config.IPRangeStart,
+			m.Config.IPRangeEnd)
+
+		rangeStart, errStart := netip.ParseAddr(m.Config.IPRangeStart)
+		rangeEnd, errEnd := netip.ParseAddr(m.Config.IPRangeEnd)
+		inRange := func(ip string) bool {
+			addr, err := netip.ParseAddr(ip)
+			return err == nil && errStart == nil && errEnd == nil && rangeStart.Compare(addr) <= 0 && addr.Compare(rangeEnd) <= 0
+		}
+
+		dynamicLeases := 0
+		dynamicLeasesInRange := 0
+		for _, lease := range m.DynamicLeases {
+			if !c.toBool(lease.IsStatic) {
+				dynamicLeases++
+				if inRange(lease.IP) {
+					dynamicLeasesInRange++
 				}
 			}
-		} else {
-			getMetricSuccessful = false
-			log.Error.Println(err)
+			if c.hostDetails {
+				c.collectGauge(ch, lease.LeaseRemaining, promDescDhcpLeaseRemaining,
+					strings.ToLower(lease.Mac),
+					lease.Hostname,
+					lease.IP,
+					c.toString(lease.IsStatic))
+			}
 		}
-	}()
+		ch <- prometheus.MustNewConstMetric(promDescDhcpDynamicLeaseTotal, prometheus.GaugeValue, float64(dynamicLeases))
+		ch <- prometheus.MustNewConstMetric(promDescDhcpStaticLeaseTotal, prometheus.GaugeValue, float64(len(m.StaticLeases)))
 
-	wg.Wait()
+		if errStart == nil && errEnd == nil && rangeStart.Is4() && rangeEnd.Is4() {
+			start := rangeStart.As4()
+			end := rangeEnd.As4()
+			poolSize := int64(binary.BigEndian.Uint32(end[:])) - int64(binary.BigEndian.Uint32(start[:])) + 1
+			if poolSize > 0 {
+				ch <- prometheus.MustNewConstMetric(promDescDhcpPoolSize, prometheus.GaugeValue, float64(poolSize))
+				ch <- prometheus.MustNewConstMetric(promDescDhcpPoolUsage, prometheus.GaugeValue, float64(dynamicLeasesInRange)/float64(poolSize))
+			}
+		}
 
-	ch <- prometheus.MustNewConstMetric(promDescExporterInfo, prometheus.GaugeValue, c.toFloat(getMetricSuccessful),
-		c.url,
-		c.freeboxApiVersion)
+		if m.V6Config != nil {
+			ch <- prometheus.MustNewConstMetric(promDescDhcpV6Info, prometheus.GaugeValue, 1,
+				c.toString(m.V6Config.Enabled),
+				c.toString(m.V6Config.UseCustomDNS),
+				strings.Join(m.V6Config.DNS, ","))
+		}
+	} else {
+		return err
+	}
+	return nil
+}
 
-	ch <- prometheus.MustNewConstMetric(promDescInfo, prometheus.GaugeValue, 1,
-		firmwareVersion,
-		mac,
-		serial,
-		boardName,
-		boxFlavor,
-		cnxType,
-		cnxState,
-		cnxMedia,
-		cnxIPv4,
-		cnxIPv6)
+func (c *Collector) collectFirewall(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect firewall")
+
+	if m, err := c.freebox.GetMetricsFirewall(); err == nil {
+		type redirKey struct {
+			protocol string
+			enabled  bool
+		}
+		redirs := map[redirKey]int{}
+
+		for _, redir := range m.Redirs {
+			enabled := c.toBool(redir.Enabled)
+			redirs[redirKey{protocol: redir.IPProto, enabled: enabled}]++
+
+			wanPort := c.toString(redir.WanPortStart)
+			if redir.WanPortEnd != nil && redir.WanPortStart != nil && *redir.WanPortEnd != *redir.WanPortStart {
+				wanPort += "-" + c.toString(redir.WanPortEnd)
+			}
+			ch <- prometheus.MustNewConstMetric(promDescFwRedirInfo, prometheus.GaugeValue, 1,
+				strconv.FormatInt(redir.ID, 10),
+				redir.IPProto,
+				wanPort,
+				redir.LanIP,
+				c.toString(redir.LanPort),
+				redir.SrcIP,
+				redir.Comment,
+				c.toString(enabled))
+		}
+		for k, count := range redirs {
+			ch <- prometheus.MustNewConstMetric(promDescFwRedirTotal, prometheus.GaugeValue, float64(count),
+				k.protocol,
+				c.toString(k.enabled))
+		}
+
+		for _, port := range m.IncomingPorts {
+			c.collectGauge(ch, port.InPort, promDescFwIncomingPort,
+				port.ID,
+				port.Type,
+				c.toString(port.Enabled),
+				c.toString(port.Active))
+		}
+
+		c.collectBool(ch, m.Dmz.Enabled, promDescFwDmzEnabled, m.Dmz.IP)
+	} else {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectIgd(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect UPnP IGD")
+
+	if m, err := c.freebox.GetMetricsIgd(); err == nil {
+		c.collectBool(ch, m.Config.Enabled, promDescIgdEnabled)
+
+		redirs := map[string]int{}
+		redirIDs := make([]string, 0, len(m.Redirs))
+		for _, redir := range m.Redirs {
+			redirs[redir.Proto]++
+			redirIDs = append(redirIDs, redir.ID)
+
+			hostName := ""
+			if redir.Host != nil {
+				hostName = c.hostNamePolicy.resolve(redir.Host)
+			}
+			ch <- prometheus.MustNewConstMetric(promDescIgdRedirInfo, prometheus.GaugeValue, 1,
+				redir.ID,
+				redir.Proto,
+				hostName,
+				redir.Desc,
+				redir.ExtSrcIP,
+				c.toString(redir.ExtPort),
+				redir.IntIP,
+				c.toString(redir.IntPort))
+			c.collectGauge(ch, redir.Remaining, promDescIgdRedirRemaining, redir.ID)
+		}
+		for protocol, count := range redirs {
+			ch <- prometheus.MustNewConstMetric(promDescIgdRedirTotal, prometheus.GaugeValue, float64(count), protocol)
+		}
+		ch <- prometheus.MustNewConstMetric(promDescIgdRedirNew, prometheus.CounterValue, float64(c.countNewIgdRedirs(redirIDs)))
+	} else {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectVpnServer(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect VPN server")
+
+	if m, err := c.freebox.GetMetricsVpnServer(); err == nil {
+		for _, server := range m.Servers {
+			c.collectStateSet(ch, promDescVpnServerState, server.State,
+				[]string{"stopped", "starting", "started", "stopping", "error"},
+				server.Name,
+				server.Type)
+			c.collectGauge(ch, server.ConnectionCount, promDescVpnServerConnectionTotal, server.Name, server.Type)
+			c.collectGauge(ch, server.AuthConnectionCount, promDescVpnServerAuthConnectionTotal, server.Name, server.Type)
+		}
+		ch <- prometheus.MustNewConstMetric(promDescVpnServerUserTotal, prometheus.GaugeValue, float64(len(m.Users)))
+
+		if c.hostDetails {
+			for _, connection := range m.Connections {
+				c.collectCounter(ch, connection.RxBytes, promDescVpnServerConnectionBytes,
+					connection.ID,
+					connection.Vpn,
+					connection.User,
+					connection.SrcIP,
+					"rx")
+				c.collectCounter(ch, connection.TxBytes, promDescVpnServerConnectionBytes,
+					connection.ID,
+					connection.Vpn,
+					connection.User,
+					connection.SrcIP,
+					"tx")
+				c.collectGauge(ch, connection.AuthTime, promDescVpnServerConnectionAuthTime,
+					connection.ID,
+					connection.Vpn,
+					connection.User,
+					connection.SrcIP)
+			}
+		}
+	} else {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectVpnClient(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect VPN client")
+
+	if m, err := c.freebox.GetMetricsVpnClient(); err == nil {
+		for _, config := range m.Configs {
+			ch <- prometheus.MustNewConstMetric(promDescVpnClientConfigInfo, prometheus.GaugeValue, 1,
+				config.ID,
+				config.Type,
+				config.Description,
+				c.toString(config.Active))
+		}
+
+		status := m.Status
+		ip := ""
+		if status.IP != nil {
+			ip = status.IP.IPMask
+		}
+		ch <- prometheus.MustNewConstMetric(promDescVpnClientInfo, prometheus.GaugeValue, 1,
+			c.toString(status.Enabled),
+			status.ActiveVpn,
+			status.ActiveVpnDescription,
+			status.Type,
+			ip,
+			status.LastError)
+		c.collectStateSet(ch, promDescVpnClientState, status.State,
+			[]string{"going_up", "up", "going_down", "down"},
+			status.ActiveVpn,
+			status.Type)
+		if status.State == "up" && status.LastUp != nil {
+			ch <- prometheus.MustNewConstMetric(promDescVpnClientUptime, prometheus.GaugeValue, time.Since(time.Unix(*status.LastUp, 0)).Seconds(),
+				status.ActiveVpn,
+				status.Type)
+		}
+	} else {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectCalls(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect calls")
+
+	if m, err := c.freebox.GetMetricsCalls(); err == nil {
+		counts, durations := c.countCalls(m)
+		for callType, count := range counts {
+			ch <- prometheus.MustNewConstMetric(promDescCallTotal, prometheus.CounterValue, float64(count), callType)
+		}
+		for callType, duration := range durations {
+			ch <- prometheus.MustNewConstMetric(promDescCallDuration, prometheus.CounterValue, float64(duration), callType)
+		}
+	} else if !c.isMissingRight(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectFreeplug(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect freeplug")
+
+	if m, err := c.freebox.GetMetricsFreeplug(); err == nil {
+		for _, network := range m {
+			ch <- prometheus.MustNewConstMetric(promDescFreeplugNetworkMemberTotal, prometheus.GaugeValue, float64(len(network.Members)), network.ID)
+
+			for _, plug := range network.Members {
+				duplex := "half"
+				if c.toBool(plug.EthFullDuplex) {
+					duplex = "full"
+				}
+
+				ch <- prometheus.MustNewConstMetric(promDescFreeplugInfo, prometheus.GaugeValue, 1,
+					network.ID,
+					plug.ID,
+					plug.Model,
+					plug.NetRole,
+					c.toString(plug.Local))
+				c.collectBool(ch, plug.HasNetwork, promDescFreeplugHasNetwork, network.ID, plug.ID)
+				// the rates are -1 when not available
+				if plug.RxRate != nil && *plug.RxRate >= 0 {
+					c.collectGaugeWithFactor(ch, plug.RxRate, 1e6/8, promDescFreeplugRateBytes, network.ID, plug.ID, "rx")
+				}
+				if plug.TxRate != nil && *plug.TxRate >= 0 {
+					c.collectGaugeWithFactor(ch, plug.TxRate, 1e6/8, promDescFreeplugRateBytes, network.ID, plug.ID, "tx")
+				}
+				ch <- prometheus.MustNewConstMetric(promDescFreeplugEthLink, prometheus.GaugeValue, c.toFloat(plug.EthPortStatus == "up"),
+					network.ID,
+					plug.ID,
+					duplex)
+				c.collectGaugeWithFactor(ch, plug.EthSpeed, 1e6/8, promDescFreeplugEthSpeedBytes, network.ID, plug.ID)
+			}
+		}
+	} else if c.isUnsupported(err) {
+		// the Freeplugs are not supported by all the models
+	} else {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectHome(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect home")
+
+	if m, err := c.freebox.GetMetricsHome(); err == nil {
+		for _, adapter := range m.Adapters {
+			ch <- prometheus.MustNewConstMetric(promDescHomeAdapterInfo, prometheus.GaugeValue, 1,
+				strconv.FormatInt(adapter.ID, 10),
+				adapter.Label,
+				adapter.Status)
+		}
+
+		categories := map[string]int{}
+		nodeNames := map[int64]string{}
+		for _, node := range m.Nodes {
+			nodeID := strconv.FormatInt(node.ID, 10)
+			categories[node.Category]++
+			nodeNames[node.ID] = node.Label
+
+			ch <- prometheus.MustNewConstMetric(promDescHomeNodeInfo, prometheus.GaugeValue, 1,
+				nodeID,
+				node.Label,
+				node.Category,
+				strconv.FormatInt(node.AdapterID, 10),
+				node.Status)
+			c.collectGauge(ch, node.LastSeen, promDescHomeNodeLastSeen, nodeID, node.Label, node.Category)
+
+			batteryCollected := false
+			for _, endpoint := range node.ShowEndpoints {
+				if endpoint.EpType != "signal" {
+					continue
+				}
+				value, ok := c.toNumber(endpoint.Value)
+				if !ok {
+					continue
+				}
+				if endpoint.Name == "battery" {
+					// only one battery level per node
+					if batteryCollected {
+						continue
+					}
+					batteryCollected = true
+					ch <- prometheus.MustNewConstMetric(promDescHomeNodeBattery, prometheus.GaugeValue, value,
+						nodeID,
+						node.Label,
+						node.Category)
+				} else {
+					ch <- prometheus.MustNewConstMetric(promDescHomeNodeValue, prometheus.GaugeValue, value,
+						nodeID,
+						node.Label,
+						node.Category,
+						strconv.FormatInt(endpoint.ID, 10),
+						endpoint.Name,
+						endpoint.Ui.Unit)
+				}
+			}
+		}
+		for category, count := range categories {
+			ch <- prometheus.MustNewConstMetric(promDescHomeNodeTotal, prometheus.GaugeValue, float64(count), category)
+		}
+
+		for _, tile := range m.Tiles {
+			if tile.Type != "alarm_control" {
+				continue
+			}
+			for _, data := range tile.Data {
+				if state, ok := data.Value.(string); ok && data.Name == "state" {
+					c.collectStateSet(ch, promDescHomeAlarmState, state,
+						[]string{"idle", "alarm1_arming", "alarm1_armed", "alarm2_arming", "alarm2_armed", "alert"},
+						strconv.FormatInt(tile.NodeID, 10),
+						nodeNames[tile.NodeID])
+				}
+			}
+		}
+	} else if c.isUnsupported(err) {
+		// the home automation is only available on some models (Freebox Delta)
+	} else if !c.isMissingRight(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectVM(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect VM")
+
+	if m, err := c.freebox.GetMetricsVM(); err == nil {
+		c.collectGauge(ch, m.Info.TotalCpus, promDescVMHostCPUTotal)
+		c.collectGauge(ch, m.Info.UsedCpus, promDescVMHostCPUUsed)
+		c.collectGaugeWithFactor(ch, m.Info.TotalMemory, 1024*1024, promDescVMHostMemoryTotalBytes)
+		c.collectGaugeWithFactor(ch, m.Info.UsedMemory, 1024*1024, promDescVMHostMemoryUsedBytes)
+		ch <- prometheus.MustNewConstMetric(promDescVMHostUsbPortTotal, prometheus.GaugeValue, float64(len(m.Info.UsbPorts)))
+		c.collectBool(ch, m.Info.UsbUsed, promDescVMHostUsbUsed)
+
+		for _, vm := range m.VMs {
+			vmID := strconv.FormatInt(vm.ID, 10)
+
+			ch <- prometheus.MustNewConstMetric(promDescVMInfo, prometheus.GaugeValue, 1,
+				vmID,
+				vm.Name,
+				vm.OS,
+				vm.DiskType,
+				c.toString(vm.EnableScreen),
+				strings.Join(vm.BindUsbPorts, ","))
+			c.collectStateSet(ch, promDescVMStatus, vm.Status,
+				[]string{"stopped", "running", "starting", "stopping"},
+				vmID,
+				vm.Name)
+			c.collectGauge(ch, vm.Vcpus, promDescVMVcpus, vmID, vm.Name)
+			c.collectGaugeWithFactor(ch, vm.Memory, 1024*1024, promDescVMMemoryBytes, vmID, vm.Name)
+		}
+	} else if c.isUnsupported(err) {
+		// the virtual machines are only available on some models (Freebox Delta)
+	} else if !c.isMissingRight(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectDownloads(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect downloads")
+
+	if m, err := c.freebox.GetMetricsDownloads(); err == nil {
+		c.collectGauge(ch, m.Stats.RxRate, promDescDownloadsRateBytes, "rx")
+		c.collectGauge(ch, m.Stats.TxRate, promDescDownloadsRateBytes, "tx")
+		if m.Stats.ThrottlingRate != nil {
+			c.collectGauge(ch, m.Stats.ThrottlingRate.RxRate, promDescDownloadsRateLimitBytes, "rx")
+			c.collectGauge(ch, m.Stats.ThrottlingRate.TxRate, promDescDownloadsRateLimitBytes, "tx")
+		}
+		c.collectStateSet(ch, promDescDownloadsThrottlingMode, m.Stats.ThrottlingMode,
+			[]string{"normal", "slow", "hibernate", "schedule"})
+
+		tasks := map[string]int{
+			"downloading": 0,
+			"seeding":     0,
+			"stopped":     0,
+			"error":       0,
+			"done":        0,
+		}
+		taskStatus := make(map[int64]string, len(m.Tasks))
+		for _, task := range m.Tasks {
+			tasks[task.Status]++
+			taskStatus[task.ID] = task.Status
+
+			if c.hostDetails {
+				taskID := strconv.FormatInt(task.ID, 10)
+
+				c.collectGauge(ch, task.Size, promDescDownloadsTaskSizeBytes, taskID, task.Name, task.Type)
+				c.collectGaugeWithFactor(ch, task.RxPct, 1./10000, promDescDownloadsTaskProgress, taskID, task.Name, task.Type)
+				if task.Size != nil && *task.Size > 0 {
+					c.collectGaugeWithFactor(ch, task.TxBytes, 1./float64(*task.Size), promDescDownloadsTaskUploadRatio, taskID, task.Name, task.Type)
+				}
+				c.collectGauge(ch, task.Eta, promDescDownloadsTaskEta, taskID, task.Name, task.Type)
+			}
+		}
+		for status, count := range tasks {
+			ch <- prometheus.MustNewConstMetric(promDescDownloadsTaskTotal, prometheus.GaugeValue, float64(count), status)
+		}
+		ch <- prometheus.MustNewConstMetric(promDescDownloadsTaskErrors, prometheus.CounterValue, float64(c.countDownloadErrors(taskStatus)))
+	} else if !c.isMissingRight(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectPlayer(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect player")
+
+	if m, err := c.freebox.GetMetricsPlayer(); err == nil {
+		for _, player := range m {
+			playerID := strconv.FormatInt(player.ID, 10)
+
+			c.collectBool(ch, player.Reachable, promDescPlayerReachable, playerID, player.DeviceName, player.DeviceModel)
+			c.collectBool(ch, player.APIAvailable, promDescPlayerAPIAvailable, playerID, player.DeviceName, player.DeviceModel)
+			if player.Status == nil {
+				continue
+			}
+
+			c.collectStateSet(ch, promDescPlayerPowerState, player.Status.PowerState,
+				[]string{"running", "standby"},
+				playerID,
+				player.DeviceName,
+				player.DeviceModel)
+			if app := player.Status.ForegroundApp; app != nil {
+				ch <- prometheus.MustNewConstMetric(promDescPlayerForegroundApp, prometheus.GaugeValue, 1,
+					playerID,
+					player.DeviceName,
+					player.DeviceModel,
+					app.Package)
+				if app.Context != nil && app.Context.Channel != nil {
+					c.collectGauge(ch, app.Context.Channel.ChannelNumber, promDescPlayerTvChannel,
+						playerID,
+						player.DeviceName,
+						player.DeviceModel,
+						app.Context.Channel.ChannelName)
+				}
+			}
+		}
+	} else if !c.isMissingRight(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectPvr(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect PVR")
+
+	if m, err := c.freebox.GetMetricsPvr(); err == nil {
+		c.collectGauge(ch, m.Config.MarginBefore, promDescPvrMargin, "before")
+		c.collectGauge(ch, m.Config.MarginAfter, promDescPvrMargin, "after")
+
+		// a recording may be both in the programmed and in the finished recordings.
+		// It is counted once, the state of the finished recordings being the most recent one
+		states := make(map[int64]string, len(m.Programmed)+len(m.Finished))
+		for _, programmed := range m.Programmed {
+			states[programmed.ID] = programmed.State
+		}
+		for _, finished := range m.Finished {
+			states[finished.ID] = finished.State
+		}
+
+		recordings := map[string]int{
+			"programmed": 0,
+			"running":    0,
+			"finished":   0,
+			"failed":     0,
+		}
+		failed := make(map[int64]bool, len(states))
+		for id, state := range states {
+			status := c.pvrStatus(state)
+			recordings[status]++
+			failed[id] = status == "failed"
+		}
+
+		var finishedBytes int64
+		for _, finished := range m.Finished {
+			// the size of the running recordings is still growing
+			if status := c.pvrStatus(states[finished.ID]); (status == "finished" || status == "failed") && finished.ByteSize != nil {
+				finishedBytes += *finished.ByteSize
+			}
+		}
+
+		for status, count := range recordings {
+			ch <- prometheus.MustNewConstMetric(promDescPvrRecordingTotal, prometheus.GaugeValue, float64(count), status)
+		}
+		ch <- prometheus.MustNewConstMetric(promDescPvrFinishedBytes, prometheus.GaugeValue, float64(finishedBytes))
+		ch <- prometheus.MustNewConstMetric(promDescPvrFailures, prometheus.CounterValue, float64(c.countPvrFailures(failed)))
+	} else if !c.isMissingRight(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectWifiGuestKeys(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect wifi guest keys")
+
+	if m, err := c.freebox.GetMetricsWifiCustomKeys(); err == nil {
+		validKeys := 0
+		stations := 0
+
+		for _, key := range m {
+			keyID := strconv.FormatInt(key.ID, 10)
+			if key.Remaining != nil && *key.Remaining > 0 {
+				validKeys++
+			}
+			for _, user := range key.Users {
+				if user.Host != nil && c.toBool(user.Host.Active) {
+					stations++
+				}
+			}
+
+			c.collectGauge(ch, key.Remaining, promDescWifiGuestKeyRemaining, keyID, key.Params.Description, key.Params.AccessType)
+			ch <- prometheus.MustNewConstMetric(promDescWifiGuestKeyUse, prometheus.GaugeValue, float64(len(key.Users)),
+				keyID,
+				key.Params.Description,
+				key.Params.AccessType)
+			c.collectGauge(ch, key.Params.MaxUseCount, promDescWifiGuestKeyMaxUse, keyID, key.Params.Description, key.Params.AccessType)
+		}
+
+		ch <- prometheus.MustNewConstMetric(promDescWifiGuestKeyTotal, prometheus.GaugeValue, float64(validKeys))
+		ch <- prometheus.MustNewConstMetric(promDescWifiGuestKeyStationTotal, prometheus.GaugeValue, float64(stations))
+	} else if c.isUnsupported(err) {
+		// the guest keys are not available on all the firmware versions
+	} else {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectRepeater(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect repeater")
+
+	if m, err := c.freebox.GetMetricsRepeater(); err == nil {
+		for _, repeater := range m {
+			repeaterID := strconv.FormatInt(repeater.ID, 10)
+
+			ch <- prometheus.MustNewConstMetric(promDescRepeaterInfo, prometheus.GaugeValue, 1,
+				repeaterID,
+				repeater.Name,
+				repeater.Model,
+				repeater.Sn,
+				repeater.FirmwareVersion,
+				repeater.Connection)
+			c.collectStateSet(ch, promDescRepeaterStatus, repeater.Status,
+				[]string{"starting", "running", "reboot", "updating", "reboot_failure", "update_failure", "disconnected"},
+				repeaterID,
+				repeater.Name)
+			if repeater.BootTime != nil && *repeater.BootTime > 0 && repeater.Status == "running" {
+				ch <- prometheus.MustNewConstMetric(promDescRepeaterUptime, prometheus.GaugeValue, time.Since(time.Unix(*repeater.BootTime, 0)).Seconds(),
+					repeaterID,
+					repeater.Name)
+			}
+
+			for _, station := range repeater.BackhaulStations {
+				bssid := strings.ToLower(station.Bssid)
+				c.collectGauge(ch, station.Signal, promDescRepeaterBackhaulSignal, repeaterID, repeater.Name, bssid)
+				c.collectGauge(ch, station.RxRate, promDescRepeaterBackhaulRateBytes, repeaterID, repeater.Name, bssid, "rx")
+				c.collectGauge(ch, station.TxRate, promDescRepeaterBackhaulRateBytes, repeaterID, repeater.Name, bssid, "tx")
+				// bitrate in 100kbit/s
+				if station.LastRx != nil {
+					c.collectGaugeWithFactor(ch, station.LastRx.BitRate, 100e3/8, promDescRepeaterBackhaulPhyRateBytes, repeaterID, repeater.Name, bssid, "rx")
+				}
+				if station.LastTx != nil {
+					c.collectGaugeWithFactor(ch, station.LastTx.BitRate, 100e3/8, promDescRepeaterBackhaulPhyRateBytes, repeaterID, repeater.Name, bssid, "tx")
+				}
+			}
+
+			hostsActive := 0
+			hostsInactive := 0
+			for _, host := range repeater.Hosts {
+				if host == nil {
+					continue
+				}
+				active := c.toBool(host.Active)
+				if active {
+					hostsActive++
+				} else {
+					hostsInactive++
+				}
+
+				if c.hostDetails && host.L2Ident != nil {
+					ch <- prometheus.MustNewConstMetric(promDescRepeaterHostActive, prometheus.GaugeValue, c.toFloat(active),
+						repeaterID,
+						repeater.Name,
+						host.VendorName,
+						c.hostNamePolicy.resolve(host),
+						host.HostType,
+						strings.ToLower(host.L2Ident.ID))
+				}
+			}
+			ch <- prometheus.MustNewConstMetric(promDescRepeaterHostTotal, prometheus.GaugeValue, float64(hostsActive), repeaterID, repeater.Name, "true")
+			ch <- prometheus.MustNewConstMetric(promDescRepeaterHostTotal, prometheus.GaugeValue, float64(hostsInactive), repeaterID, repeater.Name, "false")
+		}
+	} else if c.isUnsupported(err) {
+		// the repeaters API is not available on all the firmware versions
+	} else {
+		return err
+	}
+	return nil
+}
+
+func (c *Collector) collectParental(ch chan<- prometheus.Metric) error {
+	log.Debug.Println("Collect parental")
+
+	if m, err := c.freebox.GetMetricsParental(); err == nil {
+		profileNames := make(map[int64]string, len(m.Profiles))
+		for _, profile := range m.Profiles {
+			profileNames[profile.ID] = profile.Name
+		}
+
+		for _, control := range m.NetworkControls {
+			profileID := strconv.FormatInt(control.ProfileID, 10)
+			name := profileNames[control.ProfileID]
+
+			c.collectStateSet(ch, promDescParentalProfileMode, control.CurrentMode,
+				[]string{"allowed", "denied", "webonly"},
+				profileID,
+				name)
+			c.collectBool(ch, control.Override, promDescParentalProfileOverride, profileID, name)
+			if c.toBool(control.Override) && control.OverrideUntil != nil && *control.OverrideUntil > 0 {
+				c.collectGauge(ch, control.OverrideUntil, promDescParentalProfileOverrideEnd, profileID, name)
+			}
+			ch <- prometheus.MustNewConstMetric(promDescParentalProfileHostTotal, prometheus.GaugeValue, float64(len(control.Macs)), profileID, name)
+		}
+	} else if !c.isMissingRight(err) {
+		return err
+	}
+	return nil
 }
 
 func (c *Collector) collectXdslStats(ch chan<- prometheus.Metric, stats *fbx.MetricsFreeboxConnectionXdslStats, dir string) {
@@ -544,6 +2120,245 @@ func (c *Collector) collectXdslStats(ch chan<- prometheus.Metric, stats *fbx.Met
 	}
 }
 
+// collectStateSet exports one metric per state: 1 for the current one, 0 for the others.
+// The state is the last label. An unexpected current state is exported as well
+func (c *Collector) collectStateSet(ch chan<- prometheus.Metric, desc *prometheus.Desc, current string, states []string, labels ...string) {
+	if current == "" {
+		return
+	}
+	found := false
+	for _, state := range states {
+		found = found || state == current
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, c.toFloat(state == current), append(labels[:len(labels):len(labels)], state)...)
+	}
+	if !found {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, append(labels[:len(labels):len(labels)], current)...)
+	}
+}
+
+// collectChannelWidth exports a Wi-Fi channel width such as "80" in MHz
+func (c *Collector) collectChannelWidth(ch chan<- prometheus.Metric, width string, desc *prometheus.Desc, labels ...string) {
+	if value, err := strconv.ParseInt(width, 10, 64); err == nil {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(value), labels...)
+	}
+}
+
+// collectWifiStationAggregates exports per AP metrics which do not depend on the number of stations
+func (c *Collector) collectWifiStationAggregates(ch chan<- prometheus.Metric, ap *fbx.MetricsFreeboxWifiAp, apID string) {
+	signals := []float64{}
+	rxPhyRates := []float64{}
+	txPhyRates := []float64{}
+	var rxBytes, txBytes int64
+	standards := map[string]int{
+		"ax":      0,
+		"ac":      0,
+		"n":       0,
+		"legacy":  0,
+		"unknown": 0,
+	}
+
+	for _, station := range ap.Stations {
+		if station.Signal != nil {
+			signals = append(signals, float64(*station.Signal))
+		}
+		// bitrate in 100kbit/s
+		if station.LastRx != nil && station.LastRx.BitRate != nil {
+			rxPhyRates = append(rxPhyRates, float64(*station.LastRx.BitRate)*100e3/8)
+		}
+		if station.LastTx != nil && station.LastTx.BitRate != nil {
+			txPhyRates = append(txPhyRates, float64(*station.LastTx.BitRate)*100e3/8)
+		}
+		if station.RxBytes != nil {
+			rxBytes += *station.RxBytes
+		}
+		if station.TxBytes != nil {
+			txBytes += *station.TxBytes
+		}
+
+		switch {
+		case c.toBool(station.Flags.He):
+			standards["ax"]++
+		case c.toBool(station.Flags.Vht):
+			standards["ac"]++
+		case c.toBool(station.Flags.Ht):
+			standards["n"]++
+		case c.toBool(station.Flags.Legacy):
+			standards["legacy"]++
+		default:
+			standards["unknown"]++
+		}
+	}
+
+	c.collectHistogram(ch, signals, wifiSignalBuckets, promDescWifiApStationSignalHistogram,
+		apID,
+		ap.Config.Band,
+		ap.Name)
+	c.collectHistogram(ch, rxPhyRates, wifiPhyRateBuckets, promDescWifiApStationPhyRateHistogram,
+		apID,
+		ap.Config.Band,
+		ap.Name,
+		"rx")
+	c.collectHistogram(ch, txPhyRates, wifiPhyRateBuckets, promDescWifiApStationPhyRateHistogram,
+		apID,
+		ap.Config.Band,
+		ap.Name,
+		"tx")
+	ch <- prometheus.MustNewConstMetric(promDescWifiApStationBytesSum, prometheus.GaugeValue, float64(rxBytes),
+		apID,
+		ap.Config.Band,
+		ap.Name,
+		"rx")
+	ch <- prometheus.MustNewConstMetric(promDescWifiApStationBytesSum, prometheus.GaugeValue, float64(txBytes),
+		apID,
+		ap.Config.Band,
+		ap.Name,
+		"tx")
+	for standard, count := range standards {
+		ch <- prometheus.MustNewConstMetric(promDescWifiApStationStandardTotal, prometheus.GaugeValue, float64(count),
+			apID,
+			ap.Config.Band,
+			ap.Name,
+			standard)
+	}
+}
+
+// collectWifiPlanning exports the current state of the Wi-Fi planning and its next transition.
+// now must be in the time zone of the Freebox
+func (c *Collector) collectWifiPlanning(ch chan<- prometheus.Metric, mapping []fbx.MetricsFreeboxWifiPlanningSlot, now time.Time) {
+	if len(mapping) == 0 {
+		return
+	}
+	on, next, found := wifiPlanningState(mapping, now)
+	ch <- prometheus.MustNewConstMetric(promDescWifiPlanningOn, prometheus.GaugeValue, c.toFloat(on))
+	if found {
+		ch <- prometheus.MustNewConstMetric(promDescWifiPlanningNextChange, prometheus.GaugeValue, float64(next.Unix()))
+	}
+}
+
+// wifiPlanningState returns whether the Wi-Fi planning is on at the time now and the time of its next transition.
+// The mapping splits the week in slots starting on monday 00:00, in wall clock time of the location of now
+// so that the slots stay aligned on the schedule during the daylight saving time changes
+func wifiPlanningState(mapping []fbx.MetricsFreeboxWifiPlanningSlot, now time.Time) (bool, time.Time, bool) {
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	hour, min, sec := now.Clock()
+	sinceWeekStart := time.Duration(daysSinceMonday)*24*time.Hour +
+		time.Duration(hour)*time.Hour +
+		time.Duration(min)*time.Minute +
+		time.Duration(sec)*time.Second
+	slotDuration := 7 * 24 * time.Hour / time.Duration(len(mapping))
+	slot := int(sinceWeekStart / slotDuration)
+	if slot >= len(mapping) {
+		slot = len(mapping) - 1
+	}
+
+	on := bool(mapping[slot])
+	for i := 1; i < len(mapping); i++ {
+		if bool(mapping[(slot+i)%len(mapping)]) != on {
+			// time.Date normalizes the seconds overflowing on the next days
+			next := time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, int(time.Duration(slot+i)*slotDuration/time.Second), 0, now.Location())
+			return on, next, true
+		}
+	}
+	return on, time.Time{}, false
+}
+
+// collectWifiNeighbors exports the neighbor BSS and channel usage per channel
+func (c *Collector) collectWifiNeighbors(ch chan<- prometheus.Metric, ap *fbx.MetricsFreeboxWifiAp, apID string) {
+	type channelKey struct {
+		band    string
+		channel string
+	}
+	neighbors := map[channelKey]int{}
+	signals := map[channelKey]int64{}
+
+	for _, neighbor := range ap.Neighbors {
+		if neighbor.Channel == nil {
+			continue
+		}
+		key := channelKey{band: neighbor.Band, channel: c.toString(neighbor.Channel)}
+		neighbors[key]++
+		if neighbor.Signal != nil {
+			if signal, found := signals[key]; !found || *neighbor.Signal > signal {
+				signals[key] = *neighbor.Signal
+			}
+		}
+	}
+	for key, count := range neighbors {
+		ch <- prometheus.MustNewConstMetric(promDescWifiNeighborTotal, prometheus.GaugeValue, float64(count),
+			apID,
+			key.band,
+			key.channel)
+	}
+	for key, signal := range signals {
+		ch <- prometheus.MustNewConstMetric(promDescWifiNeighborSignal, prometheus.GaugeValue, float64(signal),
+			apID,
+			key.band,
+			key.channel)
+	}
+
+	for _, usage := range ap.ChannelUsage {
+		channel := c.toString(usage.Channel)
+		c.collectGauge(ch, usage.BusyPercent, promDescWifiChannelUsage, apID, usage.Band, channel, "busy")
+		c.collectGauge(ch, usage.RxBusyPercent, promDescWifiChannelUsage, apID, usage.Band, channel, "rx_busy")
+		c.collectGauge(ch, usage.TxPercent, promDescWifiChannelUsage, apID, usage.Band, channel, "tx")
+		c.collectGauge(ch, usage.NoiseLevel, promDescWifiChannelNoise, apID, usage.Band, channel)
+	}
+}
+
+// collectHistogram exports the values as a constant histogram
+func (c *Collector) collectHistogram(ch chan<- prometheus.Metric, values []float64, buckets []float64, desc *prometheus.Desc, labels ...string) {
+	sum := 0.
+	counts := make(map[float64]uint64, len(buckets))
+	for _, bucket := range buckets {
+		counts[bucket] = 0
+	}
+	for _, value := range values {
+		sum += value
+		for _, bucket := range buckets {
+			if value <= bucket {
+				counts[bucket]++
+			}
+		}
+	}
+	ch <- prometheus.MustNewConstHistogram(desc, uint64(len(values)), sum, counts, labels...)
+}
+
+func (c *Collector) collectAggregation(ch chan<- prometheus.Metric, aggregation *fbx.MetricsFreeboxConnectionAggregation) {
+	c.collectStateSet(ch, promDescConnectionAggregationState, aggregation.State,
+		[]string{"disabled", "starting", "established", "error"})
+
+	var rxTotal, txTotal int64
+	for _, stats := range aggregation.Tunnel {
+		if stats != nil && stats.RxUsedRate != nil {
+			rxTotal += *stats.RxUsedRate
+		}
+		if stats != nil && stats.TxUsedRate != nil {
+			txTotal += *stats.TxUsedRate
+		}
+	}
+
+	lteActive := false
+	for link, stats := range aggregation.Tunnel {
+		if stats == nil {
+			continue
+		}
+		c.collectGauge(ch, stats.RxUsedRate, promDescConnectionAggregationRateBytes, link, "rx")
+		c.collectGauge(ch, stats.TxUsedRate, promDescConnectionAggregationRateBytes, link, "tx")
+		c.collectGauge(ch, stats.RxMaxRate, promDescConnectionAggregationMaxRateBytes, link, "rx")
+		c.collectGauge(ch, stats.TxMaxRate, promDescConnectionAggregationMaxRateBytes, link, "tx")
+		if rxTotal > 0 {
+			c.collectGaugeWithFactor(ch, stats.RxUsedRate, 1./float64(rxTotal), promDescConnectionAggregationShare, link, "rx")
+		}
+		if txTotal > 0 {
+			c.collectGaugeWithFactor(ch, stats.TxUsedRate, 1./float64(txTotal), promDescConnectionAggregationShare, link, "tx")
+		}
+		if link == "lte" {
+			lteActive = (stats.RxUsedRate != nil && *stats.RxUsedRate > 0) || (stats.TxUsedRate != nil && *stats.TxUsedRate > 0)
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(promDescConnectionLteActive, prometheus.GaugeValue, c.toFloat(lteActive))
+}
+
 func (c *Collector) collectBool(ch chan<- prometheus.Metric, value *bool, desc *prometheus.Desc, labels ...string) {
 	if value != nil {
 		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, c.toFloat(*value), labels...)
@@ -574,6 +2389,166 @@ func (c *Collector) collectConstWithFactor(ch chan<- prometheus.Metric, valueTyp
 	}
 }
 
+// countReboots returns the number of reboots detected by the exporter, as the uptime goes backward
+func (c *Collector) countReboots(uptime int64) uint64 {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+
+	if uptime < c.lastUptime {
+		c.reboots++
+	}
+	c.lastUptime = uptime
+	return c.reboots
+}
+
+// topN keeps the n keys with the highest count. The others are summed in the key other
+func (c *Collector) topN(counts map[string]int, n int, other string) map[string]int {
+	if len(counts) <= n {
+		return counts
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	result := make(map[string]int, n+1)
+	for i, k := range keys {
+		if i < n && k != other {
+			result[k] = counts[k]
+		} else {
+			result[other] += counts[k]
+		}
+	}
+	return result
+}
+
+// countNewIgdRedirs returns the number of UPnP redirections which appeared since the first scrape
+func (c *Collector) countNewIgdRedirs(ids []string) uint64 {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+
+	redirs := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if c.igdRedirs != nil && !c.igdRedirs[id] {
+			c.igdNew++
+		}
+		redirs[id] = true
+	}
+	c.igdRedirs = redirs
+	return c.igdNew
+}
+
+// countCalls adds the calls not seen yet and returns the total number and duration of the calls by type.
+// The calls already in the log at the first scrape are not counted
+func (c *Collector) countCalls(calls []*fbx.MetricsFreeboxCall) (map[string]uint64, map[string]int64) {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+
+	lastCallID := c.lastCallID
+	for _, call := range calls {
+		if c.callsSeen && call.ID > c.lastCallID {
+			c.calls[call.Type]++
+			if call.Duration != nil {
+				c.callsTime[call.Type] += *call.Duration
+			}
+		}
+		if call.ID > lastCallID {
+			lastCallID = call.ID
+		}
+	}
+	c.lastCallID = lastCallID
+	c.callsSeen = true
+
+	counts := make(map[string]uint64, len(c.calls))
+	for k, v := range c.calls {
+		counts[k] = v
+	}
+	durations := make(map[string]int64, len(c.callsTime))
+	for k, v := range c.callsTime {
+		durations[k] = v
+	}
+	return counts, durations
+}
+
+// countDownloadErrors returns the number of download tasks which switched to the status "error" since the first scrape
+func (c *Collector) countDownloadErrors(taskStatus map[int64]string) uint64 {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+
+	if c.downloadStatus != nil {
+		for id, status := range taskStatus {
+			if status == "error" && c.downloadStatus[id] != "error" {
+				c.downloadErrors++
+			}
+		}
+	}
+	c.downloadStatus = taskStatus
+	return c.downloadErrors
+}
+
+// countPvrFailures returns the number of recordings which failed since the first scrape
+func (c *Collector) countPvrFailures(recordingFailed map[int64]bool) uint64 {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+
+	if c.pvrFailed != nil {
+		for id, failed := range recordingFailed {
+			if failed && !c.pvrFailed[id] {
+				c.pvrFailures++
+			}
+		}
+	}
+	c.pvrFailed = recordingFailed
+	return c.pvrFailures
+}
+
+// pvrStatus returns the status of a recording (programmed/running/finished/failed) from its state
+func (c *Collector) pvrStatus(state string) string {
+	switch state {
+	case "failed", "start_error", "running_error":
+		return "failed"
+	case "starting", "running":
+		return "running"
+	case "finished":
+		return "finished"
+	default:
+		return "programmed"
+	}
+}
+
+// isUnsupported returns true if the API is not available on this model or firmware.
+// As it is expected on most of the boxes, it is only logged in debug
+func (c *Collector) isUnsupported(err error) bool {
+	if !errors.Is(err, fbx.ErrUnsupported) {
+		return false
+	}
+	log.Debug.Println(err)
+	return true
+}
+
+// isMissingRight returns true if the error is due to a permission not granted to the application.
+// As it requires an action of the user, it is only logged once
+func (c *Collector) isMissingRight(err error) bool {
+	if !errors.Is(err, fbx.ErrInsufficientRights) {
+		return false
+	}
+
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+
+	if msg := err.Error(); !c.missingRights[msg] {
+		c.missingRights[msg] = true
+		log.Warning.Println(msg)
+	}
+	return true
+}
+
 func (c *Collector) toString(i interface{}) string {
 	if val := reflect.ValueOf(i); val.Kind() == reflect.Ptr {
 		if !val.IsNil() {
@@ -614,6 +2589,17 @@ func (c *Collector) toString(i interface{}) string {
 	return "unknown"
 }
 
+// toNumber converts a JSON value to a float. Booleans are converted to 0/1
+func (c *Collector) toNumber(i interface{}) (float64, bool) {
+	switch v := i.(type) {
+	case float64:
+		return v, true
+	case bool:
+		return c.toFloat(v), true
+	}
+	return 0, false
+}
+
 func (c *Collector) toBool(b *bool) bool {
 	return b != nil && *b
 }
@@ -625,7 +2611,7 @@ func (c *Collector) toFloat(b bool) float64 {
 	return 0
 }
 
-func NewCollector(filename string, discovery fbx.FreeboxDiscovery, forceApiVersion int, hostDetails, debug bool) *Collector {
+func NewCollector(filename string, discovery fbx.FreeboxDiscovery, forceApiVersion int, hostDetails bool, hostNamePolicy hostNamePolicy, location *time.Location, debug bool) *Collector {
 	newConfig := false
 	var conn *fbx.FreeboxConnection
 	if r, err := os.Open(filename); err == nil {
@@ -667,9 +2653,22 @@ func NewCollector(filename string, discovery fbx.FreeboxDiscovery, forceApiVersi
 
 	return &Collector{
 		hostDetails:       hostDetails,
+		hostNamePolicy:    hostNamePolicy,
+		location:          location,
 		freeboxApiVersion: apiVersion.APIVersion,
 		url:               url,
 		freebox:           fbx.NewFreeboxClient(conn, queryVersion),
+		calls: map[string]uint64{
+			"accepted": 0,
+			"missed":   0,
+			"outgoing": 0,
+		},
+		callsTime: map[string]int64{
+			"accepted": 0,
+			"missed":   0,
+			"outgoing": 0,
+		},
+		missingRights: map[string]bool{},
 	}
 }
 
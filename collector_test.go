@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trazfr/freebox-exporter/fbx"
+)
+
+// planningSlot returns the index of the 30 minutes slot of the Wi-Fi planning. day=0 is monday
+func planningSlot(day, hour, min int) int {
+	return day*48 + hour*2 + min/30
+}
+
+// planningOffBetween returns a planning with the Wi-Fi off in the slots [from, to), wrapping at the end of the week
+func planningOffBetween(from, to int) []fbx.MetricsFreeboxWifiPlanningSlot {
+	mapping := make([]fbx.MetricsFreeboxWifiPlanningSlot, 7*48)
+	for i := range mapping {
+		mapping[i] = true
+	}
+	for i := from; i != to; i = (i + 1) % len(mapping) {
+		mapping[i] = false
+	}
+	return mapping
+}
+
+func TestWifiPlanningState(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allOn := planningOffBetween(0, 0)
+
+	tests := []struct {
+		name      string
+		mapping   []fbx.MetricsFreeboxWifiPlanningSlot
+		now       time.Time
+		wantOn    bool
+		wantNext  time.Time
+		wantFound bool
+	}{
+		{
+			name:      "off slot",
+			mapping:   planningOffBetween(planningSlot(2, 10, 0), planningSlot(2, 12, 0)),
+			now:       time.Date(2026, time.January, 14, 10, 15, 0, 0, paris),
+			wantOn:    false,
+			wantNext:  time.Date(2026, time.January, 14, 12, 0, 0, 0, paris),
+			wantFound: true,
+		},
+		{
+			name:      "on slot",
+			mapping:   planningOffBetween(planningSlot(2, 10, 0), planningSlot(2, 12, 0)),
+			now:       time.Date(2026, time.January, 14, 9, 59, 59, 0, paris),
+			wantOn:    true,
+			wantNext:  time.Date(2026, time.January, 14, 10, 0, 0, 0, paris),
+			wantFound: true,
+		},
+		{
+			name:      "exporter in UTC",
+			mapping:   planningOffBetween(planningSlot(2, 10, 0), planningSlot(2, 12, 0)),
+			now:       time.Date(2026, time.January, 14, 9, 15, 0, 0, time.UTC).In(paris),
+			wantOn:    false,
+			wantNext:  time.Date(2026, time.January, 14, 12, 0, 0, 0, paris),
+			wantFound: true,
+		},
+		{
+			name:      "next change in the next week",
+			mapping:   planningOffBetween(planningSlot(6, 23, 30), planningSlot(0, 0, 30)),
+			now:       time.Date(2026, time.January, 18, 23, 45, 0, 0, paris),
+			wantOn:    false,
+			wantNext:  time.Date(2026, time.January, 19, 0, 30, 0, 0, paris),
+			wantFound: true,
+		},
+		{
+			name:      "after the switch to summer time",
+			mapping:   planningOffBetween(planningSlot(6, 20, 0), planningSlot(6, 21, 0)),
+			now:       time.Date(2026, time.March, 29, 20, 15, 0, 0, paris),
+			wantOn:    false,
+			wantNext:  time.Date(2026, time.March, 29, 21, 0, 0, 0, paris),
+			wantFound: true,
+		},
+		{
+			name:      "after the switch to winter time",
+			mapping:   planningOffBetween(planningSlot(6, 18, 0), planningSlot(6, 19, 0)),
+			now:       time.Date(2026, time.October, 25, 12, 10, 0, 0, paris),
+			wantOn:    true,
+			wantNext:  time.Date(2026, time.October, 25, 18, 0, 0, 0, paris),
+			wantFound: true,
+		},
+		{
+			name:      "no change",
+			mapping:   allOn,
+			now:       time.Date(2026, time.January, 14, 10, 15, 0, 0, paris),
+			wantOn:    true,
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			on, next, found := wifiPlanningState(tt.mapping, tt.now)
+			if on != tt.wantOn {
+				t.Errorf("on = %v, want %v", on, tt.wantOn)
+			}
+			if found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+			if found && !next.Equal(tt.wantNext) {
+				t.Errorf("next = %v, want %v", next, tt.wantNext)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package fbx
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/trazfr/freebox-exporter/log"
@@ -101,11 +104,50 @@ type MetricsFreeboxConnectionFtth struct {
 	SfpPwrRx          *int64 `json:"sfp_pwr_rx"`
 }
 
+// MetricsFreeboxConnectionLte undocumented. Only on the models with a 4G module
+type MetricsFreeboxConnectionLte struct {
+	Enabled *bool  `json:"enabled"`
+	State   string `json:"state"`
+	Radio   *struct {
+		Associated  *bool  `json:"associated"`
+		SignalLevel *int64 `json:"signal_level"`
+		Bands       []*struct {
+			Band      *int64 `json:"band"`
+			Bandwidth *int64 `json:"bandwidth"`
+			Enabled   *bool  `json:"enabled"`
+			Pci       *int64 `json:"pci"`
+			Rsrp      *int64 `json:"rsrp"`
+			Rsrq      *int64 `json:"rsrq"`
+			Rssi      *int64 `json:"rssi"`
+			Sinr      *int64 `json:"sinr"`
+		} `json:"bands"`
+	} `json:"radio"`
+}
+
+// MetricsFreeboxConnectionAggregation undocumented. Aggregation of the wired link and the 4G link
+type MetricsFreeboxConnectionAggregation struct {
+	Enabled *bool                                                    `json:"enabled"`
+	State   string                                                   `json:"state"`
+	Tunnel  map[string]*MetricsFreeboxConnectionAggregationLinkStats `json:"tunnel"` // xdsl/ftth/lte
+}
+
+// MetricsFreeboxConnectionAggregationLinkStats undocumented
+type MetricsFreeboxConnectionAggregationLinkStats struct {
+	RxFlowsRate *int64 `json:"rx_flows_rate"`
+	RxMaxRate   *int64 `json:"rx_max_rate"`
+	RxUsedRate  *int64 `json:"rx_used_rate"`
+	TxFlowsRate *int64 `json:"tx_flows_rate"`
+	TxMaxRate   *int64 `json:"tx_max_rate"`
+	TxUsedRate  *int64 `json:"tx_used_rate"`
+}
+
 // MetricsFreeboxConnectionAll is the result of GetMetricsConnection()
 type MetricsFreeboxConnectionAll struct {
 	MetricsFreeboxConnection
-	Xdsl *MetricsFreeboxConnectionXdsl
-	Ftth *MetricsFreeboxConnectionFtth
+	Xdsl        *MetricsFreeboxConnectionXdsl
+	Ftth        *MetricsFreeboxConnectionFtth
+	Lte         *MetricsFreeboxConnectionLte
+	Aggregation *MetricsFreeboxConnectionAggregation
 }
 
 // MetricsFreeboxSwitch https://dev.freebox.fr/sdk/os/switch/
@@ -164,8 +206,53 @@ type MetricsFreeboxSwitchPortStats struct {
 
 // MetricsFreeboxWifi https://dev.freebox.fr/sdk/os/wifi/
 type MetricsFreeboxWifi struct {
-	Ap  []*MetricsFreeboxWifiAp
-	Bss []*MetricsFreeboxWifiBss
+	Config     *MetricsFreeboxWifiConfig
+	Planning   *MetricsFreeboxWifiPlanning
+	MacFilters []*MetricsFreeboxWifiMacFilter
+	Ap         []*MetricsFreeboxWifiAp
+	Bss        []*MetricsFreeboxWifiBss
+}
+
+// MetricsFreeboxWifiConfig https://dev.freebox.fr/sdk/os/wifi/#WifiGlobalConfig
+type MetricsFreeboxWifiConfig struct {
+	Enabled        *bool  `json:"enabled"`
+	PowerSaving    *bool  `json:"power_saving"`
+	MacFilterState string `json:"mac_filter_state"`
+}
+
+// MetricsFreeboxWifiPlanning https://dev.freebox.fr/sdk/os/wifi/#WifiPlanning
+type MetricsFreeboxWifiPlanning struct {
+	UsePlanning *bool                            `json:"use_planning"`
+	Resolution  *int64                           `json:"resolution"`
+	Mapping     []MetricsFreeboxWifiPlanningSlot `json:"mapping"` // the week split in slots, starting on monday 00:00
+}
+
+// MetricsFreeboxWifiPlanningSlot is true if the Wi-Fi is on during the slot
+type MetricsFreeboxWifiPlanningSlot bool
+
+// UnmarshalJSON accepts either a boolean or "on"/"off"
+func (s *MetricsFreeboxWifiPlanningSlot) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case bool:
+		*s = MetricsFreeboxWifiPlanningSlot(v)
+	case string:
+		*s = MetricsFreeboxWifiPlanningSlot(v == "on")
+	default:
+		return fmt.Errorf("unexpected Wi-Fi planning slot %s", string(data))
+	}
+	return nil
+}
+
+// MetricsFreeboxWifiMacFilter https://dev.freebox.fr/sdk/os/wifi/#WifiMacFilter
+type MetricsFreeboxWifiMacFilter struct {
+	ID      string `json:"id"`
+	Mac     string `json:"mac"`
+	Type    string `json:"type"` // whitelist/blacklist
+	Comment string `json:"comment"`
 }
 
 // MetricsFreeboxWifiAp https://dev.freebox.fr/sdk/os/wifi/#WifiAp
@@ -192,7 +279,31 @@ type MetricsFreeboxWifiAp struct {
 		} `json:"ht"`
 	} `json:"config"`
 
-	Stations []*MetricsFreeboxWifiStation `json:"-"`
+	Stations     []*MetricsFreeboxWifiStation      `json:"-"`
+	Neighbors    []*MetricsFreeboxWifiNeighbor     `json:"-"`
+	ChannelUsage []*MetricsFreeboxWifiChannelUsage `json:"-"`
+}
+
+// MetricsFreeboxWifiNeighbor https://dev.freebox.fr/sdk/os/wifi/#WifiNeighbor
+type MetricsFreeboxWifiNeighbor struct {
+	Bssid            string `json:"bssid"`
+	Ssid             string `json:"ssid"`
+	Band             string `json:"band"`
+	Channel          *int64 `json:"channel"`
+	SecondaryChannel *int64 `json:"secondary_channel"`
+	ChannelWidth     string `json:"channel_width"`
+	Signal           *int64 `json:"signal"`
+	LastSeen         *int64 `json:"last_seen"`
+}
+
+// MetricsFreeboxWifiChannelUsage https://dev.freebox.fr/sdk/os/wifi/#WifiChannelUsage
+type MetricsFreeboxWifiChannelUsage struct {
+	Band          string `json:"band"`
+	Channel       *int64 `json:"channel"`
+	NoiseLevel    *int64 `json:"noise_level"`
+	BusyPercent   *int64 `json:"busy_percent"`
+	RxBusyPercent *int64 `json:"rx_busy_percent"`
+	TxPercent     *int64 `json:"tx_percent"`
 }
 
 // MetricsFreeboxWifiStation https://dev.freebox.fr/sdk/os/wifi/#WifiStation
@@ -214,6 +325,7 @@ type MetricsFreeboxWifiStation struct {
 		Legacy     *bool `json:"legacy"`
 		Ht         *bool `json:"ht"`
 		Vht        *bool `json:"vht"`
+		He         *bool `json:"he"` // undocumented
 		Authorized *bool `json:"authorized"`
 	} `json:"flags"`
 	LastRx *MetricsFreeboxWifiStationStats `json:"last_rx"`
@@ -292,9 +404,549 @@ type MetricsFreeboxLanHost struct {
 	} `json:"l3connectivities"`
 }
 
+// MetricsFreeboxStorage https://dev.freebox.fr/sdk/os/storage/
+type MetricsFreeboxStorage struct {
+	Disks      []*MetricsFreeboxStorageDisk
+	Partitions []*MetricsFreeboxStoragePartition
+}
+
+// MetricsFreeboxStorageDisk https://dev.freebox.fr/sdk/os/storage/#StorageDisk
+type MetricsFreeboxStorageDisk struct {
+	ID                 int64  `json:"id"`
+	Type               string `json:"type"`
+	State              string `json:"state"`
+	Connector          *int64 `json:"connector"`
+	Model              string `json:"model"`
+	Serial             string `json:"serial"`
+	Firmware           string `json:"firmware"`
+	TableType          string `json:"table_type"`
+	TotalBytes         *int64 `json:"total_bytes"`
+	Temp               *int64 `json:"temp"`
+	Spinning           *bool  `json:"spinning"`
+	Idle               *bool  `json:"idle"`
+	IdleDuration       *int64 `json:"idle_duration"`
+	ActiveDuration     *int64 `json:"active_duration"`
+	TimeBeforeSpindown *int64 `json:"time_before_spindown"`
+	ReadRequests       *int64 `json:"read_requests"`
+	ReadErrorRequests  *int64 `json:"read_error_requests"`
+	WriteRequests      *int64 `json:"write_requests"`
+	WriteErrorRequests *int64 `json:"write_error_requests"`
+	ReadBytes          *int64 `json:"read_bytes"`  // undocumented
+	WriteBytes         *int64 `json:"write_bytes"` // undocumented
+}
+
+// MetricsFreeboxStoragePartition https://dev.freebox.fr/sdk/os/storage/#StoragePartition
+type MetricsFreeboxStoragePartition struct {
+	ID         int64  `json:"id"`
+	DiskID     int64  `json:"disk_id"`
+	Type       string `json:"type"`
+	State      string `json:"state"`
+	FsckResult string `json:"fsck_result"`
+	FsType     string `json:"fstype"`
+	Label      string `json:"label"`
+	Path       string `json:"path"`
+	TotalBytes *int64 `json:"total_bytes"`
+	FreeBytes  *int64 `json:"free_bytes"`
+	UsedBytes  *int64 `json:"used_bytes"`
+}
+
+// MetricsFreeboxStorageRaid https://dev.freebox.fr/sdk/os/storage/#RaidArray
+type MetricsFreeboxStorageRaid struct {
+	ID                   int64  `json:"id"`
+	Name                 string `json:"name"`
+	Level                string `json:"level"`
+	State                string `json:"state"`
+	SysfsState           string `json:"sysfs_state"`
+	SyncAction           string `json:"sync_action"`
+	Degraded             *bool  `json:"degraded"`
+	RaidDisks            *int64 `json:"raid_disks"`
+	SyncSpeed            *int64 `json:"sync_speed"`
+	SyncCompletedPos     *int64 `json:"sync_completed_pos"`
+	SyncCompletedEnd     *int64 `json:"sync_completed_end"`
+	SyncCompletedPercent *int64 `json:"sync_completed_percent"`
+	MismatchCount        *int64 `json:"mismatch_count"`
+	Members              []*struct {
+		ID                  int64                      `json:"id"`
+		Role                string                     `json:"role"`
+		TotalBytes          *int64                     `json:"total_bytes"`
+		CorrectedReadErrors *int64                     `json:"corrected_read_errors"`
+		Disk                *MetricsFreeboxStorageDisk `json:"disk"`
+	} `json:"members"`
+}
+
+// MetricsFreeboxDhcp https://dev.freebox.fr/sdk/os/dhcp/
+type MetricsFreeboxDhcp struct {
+	Config        *MetricsFreeboxDhcpConfig
+	DynamicLeases []*MetricsFreeboxDhcpDynamicLease
+	StaticLeases  []*MetricsFreeboxDhcpStaticLease
+	V6Config      *MetricsFreeboxDhcpV6Config
+}
+
+// MetricsFreeboxDhcpConfig https://dev.freebox.fr/sdk/os/dhcp/#DhcpConfig
+type MetricsFreeboxDhcpConfig struct {
+	Enabled         *bool    `json:"enabled"`
+	StickyAssign    *bool    `json:"sticky_assign"`
+	Gateway         string   `json:"gateway"`
+	Netmask         string   `json:"netmask"`
+	IPRangeStart    string   `json:"ip_range_start"`
+	IPRangeEnd      string   `json:"ip_range_end"`
+	AlwaysBroadcast *bool    `json:"always_broadcast"`
+	DNS             []string `json:"dns"`
+}
+
+// MetricsFreeboxDhcpDynamicLease https://dev.freebox.fr/sdk/os/dhcp/#DhcpDynamicLease
+type MetricsFreeboxDhcpDynamicLease struct {
+	Mac            string                 `json:"mac"`
+	Hostname       string                 `json:"hostname"`
+	Host           *MetricsFreeboxLanHost `json:"host"`
+	IP             string                 `json:"ip"`
+	LeaseRemaining *int64                 `json:"lease_remaining"`
+	AssignTime     *int64                 `json:"assign_time"`
+	RefreshTime    *int64                 `json:"refresh_time"`
+	IsStatic       *bool                  `json:"is_static"`
+}
+
+// MetricsFreeboxDhcpStaticLease https://dev.freebox.fr/sdk/os/dhcp/#DhcpStaticLease
+type MetricsFreeboxDhcpStaticLease struct {
+	ID       string                 `json:"id"`
+	Mac      string                 `json:"mac"`
+	Comment  string                 `json:"comment"`
+	Hostname string                 `json:"hostname"`
+	IP       string                 `json:"ip"`
+	Host     *MetricsFreeboxLanHost `json:"host"`
+}
+
+// MetricsFreeboxDhcpV6Config https://dev.freebox.fr/sdk/os/dhcpv6/
+type MetricsFreeboxDhcpV6Config struct {
+	Enabled      *bool    `json:"enabled"`
+	UseCustomDNS *bool    `json:"use_custom_dns"`
+	DNS          []string `json:"dns"`
+}
+
+// MetricsFreeboxFirewall https://dev.freebox.fr/sdk/os/fw/
+type MetricsFreeboxFirewall struct {
+	Redirs        []*MetricsFreeboxFirewallRedir
+	IncomingPorts []*MetricsFreeboxFirewallIncomingPort
+	Dmz           *MetricsFreeboxFirewallDmz
+}
+
+// MetricsFreeboxFirewallRedir https://dev.freebox.fr/sdk/os/fw/#PortForwardingConfig
+type MetricsFreeboxFirewallRedir struct {
+	ID           int64                  `json:"id"`
+	Enabled      *bool                  `json:"enabled"`
+	IPProto      string                 `json:"ip_proto"`
+	WanPortStart *int64                 `json:"wan_port_start"`
+	WanPortEnd   *int64                 `json:"wan_port_end"`
+	LanIP        string                 `json:"lan_ip"`
+	LanPort      *int64                 `json:"lan_port"`
+	SrcIP        string                 `json:"src_ip"`
+	Comment      string                 `json:"comment"`
+	Hostname     string                 `json:"hostname"`
+	Host         *MetricsFreeboxLanHost `json:"host"`
+}
+
+// MetricsFreeboxFirewallIncomingPort https://dev.freebox.fr/sdk/os/fw/#IncomingPortConfig
+type MetricsFreeboxFirewallIncomingPort struct {
+	ID       string `json:"id"`
+	Enabled  *bool  `json:"enabled"`
+	Active   *bool  `json:"active"`
+	Type     string `json:"type"`
+	InPort   *int64 `json:"in_port"`
+	MinPort  *int64 `json:"min_port"`
+	MaxPort  *int64 `json:"max_port"`
+	Readonly *bool  `json:"readonly"`
+	Netns    string `json:"netns"`
+}
+
+// MetricsFreeboxFirewallDmz https://dev.freebox.fr/sdk/os/fw/#DmzConfig
+type MetricsFreeboxFirewallDmz struct {
+	Enabled *bool  `json:"enabled"`
+	IP      string `json:"ip"`
+}
+
+// MetricsFreeboxIgd https://dev.freebox.fr/sdk/os/igd/
+type MetricsFreeboxIgd struct {
+	Config *MetricsFreeboxIgdConfig
+	Redirs []*MetricsFreeboxIgdRedir
+}
+
+// MetricsFreeboxIgdConfig https://dev.freebox.fr/sdk/os/igd/#UPnPIGDConfig
+type MetricsFreeboxIgdConfig struct {
+	Enabled *bool  `json:"enabled"`
+	Version *int64 `json:"version"`
+}
+
+// MetricsFreeboxIgdRedir https://dev.freebox.fr/sdk/os/igd/#UPnPRedir
+type MetricsFreeboxIgdRedir struct {
+	ID        string                 `json:"id"`
+	Enabled   *bool                  `json:"enabled"`
+	ExtSrcIP  string                 `json:"ext_src_ip"`
+	ExtPort   *int64                 `json:"ext_port"`
+	IntIP     string                 `json:"int_ip"`
+	IntPort   *int64                 `json:"int_port"`
+	Proto     string                 `json:"proto"`
+	Desc      string                 `json:"desc"`
+	Remaining *int64                 `json:"remaining"`
+	Host      *MetricsFreeboxLanHost `json:"host"`
+}
+
+// MetricsFreeboxVpnServer https://dev.freebox.fr/sdk/os/vpn/
+type MetricsFreeboxVpnServer struct {
+	Servers     []*MetricsFreeboxVpnServerStatus
+	Connections []*MetricsFreeboxVpnServerConnection
+	Users       []*MetricsFreeboxVpnServerUser
+}
+
+// MetricsFreeboxVpnServerStatus https://dev.freebox.fr/sdk/os/vpn/#VPNServer
+type MetricsFreeboxVpnServerStatus struct {
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	State               string `json:"state"`
+	ConnectionCount     *int64 `json:"connection_count"`
+	AuthConnectionCount *int64 `json:"auth_connection_count"`
+}
+
+// MetricsFreeboxVpnServerConnection https://dev.freebox.fr/sdk/os/vpn/#VPNServerConnection
+type MetricsFreeboxVpnServerConnection struct {
+	ID            string `json:"id"`
+	Vpn           string `json:"vpn"`
+	User          string `json:"user"`
+	Authenticated *bool  `json:"authenticated"`
+	AuthTime      *int64 `json:"auth_time"`
+	SrcIP         string `json:"src_ip"`
+	SrcPort       *int64 `json:"src_port"`
+	LocalIP       string `json:"local_ip"`
+	RxBytes       *int64 `json:"rx_bytes"`
+	TxBytes       *int64 `json:"tx_bytes"`
+}
+
+// MetricsFreeboxVpnServerUser https://dev.freebox.fr/sdk/os/vpn/#VPNUser
+type MetricsFreeboxVpnServerUser struct {
+	Login         string `json:"login"`
+	IPReservation string `json:"ip_reservation"`
+}
+
+// MetricsFreeboxVpnClient https://dev.freebox.fr/sdk/os/vpn_client/
+type MetricsFreeboxVpnClient struct {
+	Configs []*MetricsFreeboxVpnClientConfig
+	Status  *MetricsFreeboxVpnClientStatus
+}
+
+// MetricsFreeboxVpnClientConfig https://dev.freebox.fr/sdk/os/vpn_client/#VPNClientConfig
+type MetricsFreeboxVpnClientConfig struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Active      *bool  `json:"active"`
+}
+
+// MetricsFreeboxVpnClientStatus https://dev.freebox.fr/sdk/os/vpn_client/#VPNClientStatus
+type MetricsFreeboxVpnClientStatus struct {
+	Enabled              *bool  `json:"enabled"`
+	ActiveVpn            string `json:"active_vpn"`
+	ActiveVpnDescription string `json:"active_vpn_description"`
+	Type                 string `json:"type"`
+	State                string `json:"state"`
+	LastError            string `json:"last_error"`
+	LastUp               *int64 `json:"last_up"`
+	LastTry              *int64 `json:"last_try"`
+	NextTry              *int64 `json:"next_try"`
+	IP                   *struct {
+		IPMask      string   `json:"ip_mask"`
+		ProviderDNS []string `json:"provider_dns"`
+		Domain      string   `json:"domain"`
+	} `json:"ip"`
+}
+
+// MetricsFreeboxCall https://dev.freebox.fr/sdk/os/call/#CallEntry
+type MetricsFreeboxCall struct {
+	ID        int64  `json:"id"`
+	Type      string `json:"type"`
+	Datetime  *int64 `json:"datetime"`
+	Number    string `json:"number"`
+	Name      string `json:"name"`
+	Duration  *int64 `json:"duration"`
+	New       *bool  `json:"new"`
+	ContactID *int64 `json:"contact_id"`
+}
+
+// MetricsFreeboxFreeplugNetwork https://dev.freebox.fr/sdk/os/freeplug/#FreeplugNetwork
+type MetricsFreeboxFreeplugNetwork struct {
+	ID      string                    `json:"id"`
+	Members []*MetricsFreeboxFreeplug `json:"members"`
+}
+
+// MetricsFreeboxFreeplug https://dev.freebox.fr/sdk/os/freeplug/#Freeplug
+type MetricsFreeboxFreeplug struct {
+	ID            string `json:"id"`
+	Local         *bool  `json:"local"`
+	NetRole       string `json:"net_role"`
+	NetID         string `json:"net_id"`
+	Model         string `json:"model"`
+	EthPortStatus string `json:"eth_port_status"`
+	EthFullDuplex *bool  `json:"eth_full_duplex"`
+	EthSpeed      *int64 `json:"eth_speed"` // Mb/s
+	HasNetwork    *bool  `json:"has_network"`
+	Inactive      *int64 `json:"inactive"`
+	RxRate        *int64 `json:"rx_rate"` // Mb/s, -1 if not available
+	TxRate        *int64 `json:"tx_rate"` // Mb/s, -1 if not available
+}
+
+// MetricsFreeboxHome https://dev.freebox.fr/sdk/os/home/
+type MetricsFreeboxHome struct {
+	Adapters []*MetricsFreeboxHomeAdapter
+	Nodes    []*MetricsFreeboxHomeNode
+	Tiles    []*MetricsFreeboxHomeTile
+}
+
+// MetricsFreeboxHomeAdapter https://dev.freebox.fr/sdk/os/home/#HomeAdapter
+type MetricsFreeboxHomeAdapter struct {
+	ID     int64  `json:"id"`
+	Label  string `json:"label"`
+	Status string `json:"status"`
+}
+
+// MetricsFreeboxHomeNode https://dev.freebox.fr/sdk/os/home/#HomeNode
+type MetricsFreeboxHomeNode struct {
+	ID            int64                         `json:"id"`
+	AdapterID     int64                         `json:"adapter"`
+	Category      string                        `json:"category"`
+	Name          string                        `json:"name"`
+	Label         string                        `json:"label"`
+	Status        string                        `json:"status"`
+	LastSeen      *int64                        `json:"last_seen"` // undocumented
+	ShowEndpoints []*MetricsFreeboxHomeEndpoint `json:"show_endpoints"`
+}
+
+// MetricsFreeboxHomeEndpoint https://dev.freebox.fr/sdk/os/home/#HomeNodeEndpoint
+type MetricsFreeboxHomeEndpoint struct {
+	ID        int64       `json:"id"`
+	EpType    string      `json:"ep_type"` // signal/slot
+	Name      string      `json:"name"`
+	Label     string      `json:"label"`
+	ValueType string      `json:"value_type"` // bool/int/float/string/void
+	Value     interface{} `json:"value"`
+	Ui        struct {
+		Unit string `json:"unit"`
+	} `json:"ui"`
+}
+
+// MetricsFreeboxHomeTile https://dev.freebox.fr/sdk/os/home/#HomeTile
+type MetricsFreeboxHomeTile struct {
+	NodeID int64  `json:"node_id"`
+	Type   string `json:"type"`
+	Label  string `json:"label"`
+	Data   []*struct {
+		EpID      int64       `json:"ep_id"`
+		Name      string      `json:"name"`
+		Label     string      `json:"label"`
+		ValueType string      `json:"value_type"`
+		Value     interface{} `json:"value"`
+	} `json:"data"`
+}
+
+// MetricsFreeboxVM https://dev.freebox.fr/sdk/os/vm/
+type MetricsFreeboxVM struct {
+	Info *MetricsFreeboxVMInfo
+	VMs  []*MetricsFreeboxVMStatus
+}
+
+// MetricsFreeboxVMInfo https://dev.freebox.fr/sdk/os/vm/#VmSystemInfo
+type MetricsFreeboxVMInfo struct {
+	TotalMemory *int64   `json:"total_memory"` // MB
+	UsedMemory  *int64   `json:"used_memory"`  // MB
+	TotalCpus   *int64   `json:"total_cpus"`
+	UsedCpus    *int64   `json:"used_cpus"`
+	UsbUsed     *bool    `json:"usb_used"`
+	UsbPorts    []string `json:"usb_ports"`
+}
+
+// MetricsFreeboxVMStatus https://dev.freebox.fr/sdk/os/vm/#VmObject
+type MetricsFreeboxVMStatus struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Mac          string   `json:"mac"`
+	OS           string   `json:"os"`
+	DiskType     string   `json:"disk_type"`
+	Memory       *int64   `json:"memory"` // MB
+	Vcpus        *int64   `json:"vcpus"`
+	Status       string   `json:"status"`
+	EnableScreen *bool    `json:"enable_screen"`
+	BindUsbPorts []string `json:"bind_usb_ports"`
+}
+
+// MetricsFreeboxDownloads https://dev.freebox.fr/sdk/os/download/
+type MetricsFreeboxDownloads struct {
+	Tasks []*MetricsFreeboxDownloadTask
+	Stats *MetricsFreeboxDownloadStats
+}
+
+// MetricsFreeboxDownloadTask https://dev.freebox.fr/sdk/os/download/#DownloadTask
+type MetricsFreeboxDownloadTask struct {
+	ID        int64  `json:"id"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+	Size      *int64 `json:"size"`
+	QueuePos  *int64 `json:"queue_pos"`
+	TxBytes   *int64 `json:"tx_bytes"`
+	RxBytes   *int64 `json:"rx_bytes"`
+	TxRate    *int64 `json:"tx_rate"`
+	RxRate    *int64 `json:"rx_rate"`
+	TxPct     *int64 `json:"tx_pct"` // in 1/100 of %
+	RxPct     *int64 `json:"rx_pct"` // in 1/100 of %
+	CreatedTs *int64 `json:"created_ts"`
+	Eta       *int64 `json:"eta"`
+	StopRatio *int64 `json:"stop_ratio"` // in 1/100
+}
+
+// MetricsFreeboxDownloadStats https://dev.freebox.fr/sdk/os/download/#DownloadStats
+type MetricsFreeboxDownloadStats struct {
+	NbTasks               *int64 `json:"nb_tasks"`
+	NbTasksActive         *int64 `json:"nb_tasks_active"`
+	RxRate                *int64 `json:"rx_rate"`
+	TxRate                *int64 `json:"tx_rate"`
+	ThrottlingMode        string `json:"throttling_mode"`
+	ThrottlingIsScheduled *bool  `json:"throttling_is_scheduled"`
+	ThrottlingRate        *struct {
+		RxRate *int64 `json:"rx_rate"`
+		TxRate *int64 `json:"tx_rate"`
+	} `json:"throttling_rate"`
+}
+
+// MetricsFreeboxPlayer https://dev.freebox.fr/sdk/os/player/#Player
+type MetricsFreeboxPlayer struct {
+	ID                int64  `json:"id"`
+	Mac               string `json:"mac"`
+	UID               string `json:"uid"`
+	DeviceName        string `json:"device_name"`
+	DeviceModel       string `json:"device_model"`
+	Reachable         *bool  `json:"reachable"`
+	APIAvailable      *bool  `json:"api_available"`
+	APIVersion        string `json:"api_version"`
+	LastTimeReachable *int64 `json:"last_time_reachable"`
+
+	Status *MetricsFreeboxPlayerStatus `json:"-"`
+}
+
+// MetricsFreeboxPlayerStatus https://dev.freebox.fr/sdk/os/player/#PlayerStatus
+type MetricsFreeboxPlayerStatus struct {
+	PowerState    string `json:"power_state"`
+	ForegroundApp *struct {
+		Package string `json:"package"`
+		Context *struct {
+			Channel *struct {
+				ChannelNumber *int64 `json:"channelNumber"`
+				ChannelName   string `json:"channelName"`
+			} `json:"channel"`
+		} `json:"context"`
+	} `json:"foreground_app"`
+}
+
+// MetricsFreeboxPvr https://dev.freebox.fr/sdk/os/pvr/
+type MetricsFreeboxPvr struct {
+	Config     *MetricsFreeboxPvrConfig
+	Programmed []*MetricsFreeboxPvrProgrammed
+	Finished   []*MetricsFreeboxPvrFinished
+}
+
+// MetricsFreeboxPvrConfig https://dev.freebox.fr/sdk/os/pvr/#PvrConfig
+type MetricsFreeboxPvrConfig struct {
+	MarginBefore *int64 `json:"margin_before"` // seconds
+	MarginAfter  *int64 `json:"margin_after"`  // seconds
+}
+
+// MetricsFreeboxPvrProgrammed https://dev.freebox.fr/sdk/os/pvr/#PvrProgrammed
+type MetricsFreeboxPvrProgrammed struct {
+	ID          int64  `json:"id"`
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	ChannelUUID string `json:"channel_uuid"`
+	Start       *int64 `json:"start"`
+	End         *int64 `json:"end"`
+}
+
+// MetricsFreeboxPvrFinished https://dev.freebox.fr/sdk/os/pvr/#PvrFinished
+type MetricsFreeboxPvrFinished struct {
+	ID          int64  `json:"id"`
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	ChannelUUID string `json:"channel_uuid"`
+	Start       *int64 `json:"start"`
+	End         *int64 `json:"end"`
+	Duration    *int64 `json:"duration"`
+	ByteSize    *int64 `json:"byte_size"`
+}
+
+// MetricsFreeboxWifiCustomKey https://dev.freebox.fr/sdk/os/wifi/#WifiCustomKey
+type MetricsFreeboxWifiCustomKey struct {
+	ID        int64  `json:"id"`
+	Remaining *int64 `json:"remaining"`
+	Params    struct {
+		Description string `json:"description"`
+		MaxUseCount *int64 `json:"max_use_count"`
+		Duration    *int64 `json:"duration"`
+		AccessType  string `json:"access_type"`
+	} `json:"params"`
+	Users []*struct {
+		Hostname string                 `json:"hostname"`
+		Mac      string                 `json:"mac"`
+		Host     *MetricsFreeboxLanHost `json:"host"`
+	} `json:"users"`
+}
+
+// MetricsFreeboxRepeater https://dev.freebox.fr/sdk/os/repeater/#Repeater
+type MetricsFreeboxRepeater struct {
+	ID               int64                        `json:"id"`
+	Name             string                       `json:"name"`
+	Model            string                       `json:"model"`
+	Sn               string                       `json:"sn"`
+	FirmwareVersion  string                       `json:"firmware_version"`
+	Status           string                       `json:"status"`
+	Connection       string                       `json:"connection"`
+	MainMac          string                       `json:"main_mac"`
+	BootTime         *int64                       `json:"boot_time"`
+	LastSeen         *int64                       `json:"last_seen"`
+	LedActivated     *bool                        `json:"led_activated"`
+	BackhaulStations []*MetricsFreeboxWifiStation `json:"backhaul_stations"` // undocumented
+
+	Hosts []*MetricsFreeboxLanHost `json:"-"`
+}
+
+// MetricsFreeboxParental https://dev.freebox.fr/sdk/os/network_control/
+type MetricsFreeboxParental struct {
+	Profiles        []*MetricsFreeboxProfile
+	NetworkControls []*MetricsFreeboxNetworkControl
+}
+
+// MetricsFreeboxProfile https://dev.freebox.fr/sdk/os/profile/#Profile
+type MetricsFreeboxProfile struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Icon string `json:"icon"`
+}
+
+// MetricsFreeboxNetworkControl https://dev.freebox.fr/sdk/os/network_control/#NetworkControl
+type MetricsFreeboxNetworkControl struct {
+	ProfileID     int64    `json:"profile_id"`
+	CurrentMode   string   `json:"current_mode"`
+	RuleMode      string   `json:"rule_mode"`
+	Override      *bool    `json:"override"`
+	OverrideMode  string   `json:"override_mode"`
+	OverrideUntil *int64   `json:"override_until"`
+	NextChange    *int64   `json:"next_change"`
+	Macs          []string `json:"macs"`
+	Hosts         []string `json:"hosts"`
+}
+
 type FreeboxClientV5 struct {
 	queryVersion int
 	conn         *FreeboxConnection
+
+	// set once the Freebox has answered that it has no 4G module (ErrUnsupported)
+	lteLock        sync.Mutex
+	lteUnsupported bool
 }
 
 func NewFreeboxClient(conn *FreeboxConnection, queryVersion int) *FreeboxClientV5 {
@@ -341,9 +993,47 @@ func (f *FreeboxClientV5) GetMetricsConnection() (*MetricsFreeboxConnectionAll,
 		result.Ftth = ftth
 	}
 
+	if f.isLteSupported() {
+		// the 4G is only available on some models (Freebox Delta, Freebox Pop)
+		// http://mafreebox.freebox.fr/api/v5/connection/lte/config/
+		lte := new(MetricsFreeboxConnectionLte)
+		if err := f.get("connection/lte/config/", lte); errors.Is(err, ErrUnsupported) {
+			f.setLteUnsupported(err)
+		} else if err != nil {
+			// may be transient (modem restarting...), try again at the next scrape
+			log.Error.Println("Could not get the LTE configuration", err)
+		} else {
+			result.Lte = lte
+
+			// http://mafreebox.freebox.fr/api/v5/connection/aggregation/
+			aggregation := new(MetricsFreeboxConnectionAggregation)
+			if err := f.get("connection/aggregation/", aggregation); err != nil {
+				log.Debug.Println("Could not get the aggregation status", err)
+			} else {
+				result.Aggregation = aggregation
+			}
+		}
+	}
+
 	return result, nil
 }
 
+func (f *FreeboxClientV5) isLteSupported() bool {
+	f.lteLock.Lock()
+	defer f.lteLock.Unlock()
+	return !f.lteUnsupported
+}
+
+// setLteUnsupported stops querying the 4G API once the Freebox has answered it has no 4G module
+func (f *FreeboxClientV5) setLteUnsupported(err error) {
+	f.lteLock.Lock()
+	defer f.lteLock.Unlock()
+	if !f.lteUnsupported {
+		log.Info.Println("The 4G API is not supported by this Freebox, it will not be queried anymore:", err)
+		f.lteUnsupported = true
+	}
+}
+
 // GetMetricsSwitch http://mafreebox.freebox.fr/api/v5/switch/status/
 func (f *FreeboxClientV5) GetMetricsSwitch() (*MetricsFreeboxSwitch, error) {
 	res := new(MetricsFreeboxSwitch)
@@ -378,7 +1068,37 @@ func (f *FreeboxClientV5) GetMetricsWifi() (*MetricsFreeboxWifi, error) {
 	res := new(MetricsFreeboxWifi)
 
 	wg := sync.WaitGroup{}
-	wg.Add(2)
+	wg.Add(5)
+
+	go func() {
+		defer wg.Done()
+
+		config := new(MetricsFreeboxWifiConfig)
+		if err := f.get("wifi/config/", config); err != nil {
+			log.Error.Println("Could not get the Wi-Fi configuration", err)
+			return
+		}
+		res.Config = config
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		planning := new(MetricsFreeboxWifiPlanning)
+		if err := f.get("wifi/planning/", planning); err != nil {
+			log.Error.Println("Could not get the Wi-Fi planning", err)
+			return
+		}
+		res.Planning = planning
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		if err := f.get("wifi/mac_filter/", &res.MacFilters); err != nil {
+			log.Error.Println("Could not get the MAC filters", err)
+		}
+	}()
 
 	go func() {
 		defer wg.Done()
@@ -397,7 +1117,7 @@ func (f *FreeboxClientV5) GetMetricsWifi() (*MetricsFreeboxWifi, error) {
 		}
 
 		wgAp := sync.WaitGroup{}
-		wgAp.Add(len(res.Ap))
+		wgAp.Add(3 * len(res.Ap))
 
 		for _, ap := range res.Ap {
 			go func(ap *MetricsFreeboxWifiAp) {
@@ -407,6 +1127,20 @@ func (f *FreeboxClientV5) GetMetricsWifi() (*MetricsFreeboxWifi, error) {
 					log.Error.Println("Could not get stations of AP", ap.ID, err)
 				}
 			}(ap)
+			go func(ap *MetricsFreeboxWifiAp) {
+				defer wgAp.Done()
+
+				if err := f.get(fmt.Sprintf("wifi/ap/%d/neighbors/", ap.ID), &ap.Neighbors); err != nil {
+					log.Error.Println("Could not get neighbors of AP", ap.ID, err)
+				}
+			}(ap)
+			go func(ap *MetricsFreeboxWifiAp) {
+				defer wgAp.Done()
+
+				if err := f.get(fmt.Sprintf("wifi/ap/%d/channel_usage/", ap.ID), &ap.ChannelUsage); err != nil {
+					log.Error.Println("Could not get channel usage of AP", ap.ID, err)
+				}
+			}(ap)
 		}
 
 		wgAp.Wait()
@@ -430,6 +1164,18 @@ func (f *FreeboxClientV5) GetMetricsWifi() (*MetricsFreeboxWifi, error) {
 	return res, nil
 }
 
+// GetMetricsWifiCustomKeys https://dev.freebox.fr/sdk/os/wifi/#custom-key
+func (f *FreeboxClientV5) GetMetricsWifiCustomKeys() ([]*MetricsFreeboxWifiCustomKey, error) {
+	res := []*MetricsFreeboxWifiCustomKey{}
+
+	// http://mafreebox.freebox.fr/api/v5/wifi/custom_key/
+	if err := f.get("wifi/custom_key/", &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // GetMetricsLan https://dev.freebox.fr/sdk/os/lan/
 func (f *FreeboxClientV5) GetMetricsLan() (*MetricsFreeboxLan, error) {
 	interfaces := []*freeboxLanInterfaces{}
@@ -470,6 +1216,331 @@ func (f *FreeboxClientV5) GetMetricsLan() (*MetricsFreeboxLan, error) {
 	return res, nil
 }
 
+// GetMetricsStorage https://dev.freebox.fr/sdk/os/storage/
+func (f *FreeboxClientV5) GetMetricsStorage() (*MetricsFreeboxStorage, error) {
+	res := new(MetricsFreeboxStorage)
+
+	// http://mafreebox.freebox.fr/api/v5/storage/disk/
+	if err := f.get("storage/disk/", &res.Disks); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/storage/partition/
+	if err := f.get("storage/partition/", &res.Partitions); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsStorageRaid https://dev.freebox.fr/sdk/os/storage/
+func (f *FreeboxClientV5) GetMetricsStorageRaid() ([]*MetricsFreeboxStorageRaid, error) {
+	res := []*MetricsFreeboxStorageRaid{}
+
+	// http://mafreebox.freebox.fr/api/v5/storage/raid/
+	if err := f.get("storage/raid/", &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsDhcp https://dev.freebox.fr/sdk/os/dhcp/
+func (f *FreeboxClientV5) GetMetricsDhcp() (*MetricsFreeboxDhcp, error) {
+	res := &MetricsFreeboxDhcp{
+		Config: new(MetricsFreeboxDhcpConfig),
+	}
+
+	// http://mafreebox.freebox.fr/api/v5/dhcp/config/
+	if err := f.get("dhcp/config/", res.Config); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/dhcp/dynamic_lease/
+	if err := f.get("dhcp/dynamic_lease/", &res.DynamicLeases); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/dhcp/static_lease/
+	if err := f.get("dhcp/static_lease/", &res.StaticLeases); err != nil {
+		return nil, err
+	}
+
+	// the DHCPv6 server is not available on all the firmware versions
+	// http://mafreebox.freebox.fr/api/v5/dhcpv6/config/
+	v6Config := new(MetricsFreeboxDhcpV6Config)
+	if err := f.get("dhcpv6/config/", v6Config); errors.Is(err, ErrUnsupported) {
+		log.Debug.Println("Could not get the DHCPv6 configuration", err)
+	} else if err != nil {
+		return nil, err
+	} else {
+		res.V6Config = v6Config
+	}
+
+	return res, nil
+}
+
+// GetMetricsFirewall https://dev.freebox.fr/sdk/os/fw/
+func (f *FreeboxClientV5) GetMetricsFirewall() (*MetricsFreeboxFirewall, error) {
+	res := &MetricsFreeboxFirewall{
+		Dmz: new(MetricsFreeboxFirewallDmz),
+	}
+
+	// http://mafreebox.freebox.fr/api/v5/fw/redir/
+	if err := f.get("fw/redir/", &res.Redirs); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/fw/incoming/
+	if err := f.get("fw/incoming/", &res.IncomingPorts); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/fw/dmz/
+	if err := f.get("fw/dmz/", res.Dmz); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsIgd https://dev.freebox.fr/sdk/os/igd/
+func (f *FreeboxClientV5) GetMetricsIgd() (*MetricsFreeboxIgd, error) {
+	res := &MetricsFreeboxIgd{
+		Config: new(MetricsFreeboxIgdConfig),
+	}
+
+	// http://mafreebox.freebox.fr/api/v5/igd/config/
+	if err := f.get("igd/config/", res.Config); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/igd/redir/
+	if err := f.get("igd/redir/", &res.Redirs); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsVpnServer https://dev.freebox.fr/sdk/os/vpn/
+func (f *FreeboxClientV5) GetMetricsVpnServer() (*MetricsFreeboxVpnServer, error) {
+	res := new(MetricsFreeboxVpnServer)
+
+	// http://mafreebox.freebox.fr/api/v5/vpn/
+	if err := f.get("vpn/", &res.Servers); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/vpn/connection/
+	if err := f.get("vpn/connection/", &res.Connections); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/vpn/user/
+	if err := f.get("vpn/user/", &res.Users); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsVpnClient https://dev.freebox.fr/sdk/os/vpn_client/
+func (f *FreeboxClientV5) GetMetricsVpnClient() (*MetricsFreeboxVpnClient, error) {
+	res := &MetricsFreeboxVpnClient{
+		Status: new(MetricsFreeboxVpnClientStatus),
+	}
+
+	// http://mafreebox.freebox.fr/api/v5/vpn_client/config/
+	if err := f.get("vpn_client/config/", &res.Configs); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/vpn_client/status/
+	if err := f.get("vpn_client/status/", res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsCalls https://dev.freebox.fr/sdk/os/call/
+// The permission "calls" is required
+func (f *FreeboxClientV5) GetMetricsCalls() ([]*MetricsFreeboxCall, error) {
+	res := []*MetricsFreeboxCall{}
+
+	// http://mafreebox.freebox.fr/api/v5/call/log/
+	if err := f.get("call/log/", &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsFreeplug https://dev.freebox.fr/sdk/os/freeplug/
+func (f *FreeboxClientV5) GetMetricsFreeplug() ([]*MetricsFreeboxFreeplugNetwork, error) {
+	res := []*MetricsFreeboxFreeplugNetwork{}
+
+	// http://mafreebox.freebox.fr/api/v5/freeplug/
+	if err := f.get("freeplug/", &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsHome https://dev.freebox.fr/sdk/os/home/
+// The permission "home" is required
+func (f *FreeboxClientV5) GetMetricsHome() (*MetricsFreeboxHome, error) {
+	res := new(MetricsFreeboxHome)
+
+	// http://mafreebox.freebox.fr/api/v5/home/adapters/
+	if err := f.get("home/adapters/", &res.Adapters); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/home/nodes/
+	if err := f.get("home/nodes/", &res.Nodes); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/home/tileset/all/
+	if err := f.get("home/tileset/all/", &res.Tiles); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsVM https://dev.freebox.fr/sdk/os/vm/
+// The permission "vm" is required
+func (f *FreeboxClientV5) GetMetricsVM() (*MetricsFreeboxVM, error) {
+	res := &MetricsFreeboxVM{
+		Info: new(MetricsFreeboxVMInfo),
+	}
+
+	// http://mafreebox.freebox.fr/api/v5/vm/info/
+	if err := f.get("vm/info/", res.Info); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/vm/
+	if err := f.get("vm/", &res.VMs); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsDownloads https://dev.freebox.fr/sdk/os/download/
+// The permission "downloader" is required
+func (f *FreeboxClientV5) GetMetricsDownloads() (*MetricsFreeboxDownloads, error) {
+	res := &MetricsFreeboxDownloads{
+		Stats: new(MetricsFreeboxDownloadStats),
+	}
+
+	// http://mafreebox.freebox.fr/api/v5/downloads/
+	if err := f.get("downloads/", &res.Tasks); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/downloads/stats/
+	if err := f.get("downloads/stats/", res.Stats); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsPlayer https://dev.freebox.fr/sdk/os/player/
+// The permission "player" is required
+func (f *FreeboxClientV5) GetMetricsPlayer() ([]*MetricsFreeboxPlayer, error) {
+	res := []*MetricsFreeboxPlayer{}
+
+	// http://mafreebox.freebox.fr/api/v5/player/
+	if err := f.get("player/", &res); err != nil {
+		return nil, err
+	}
+
+	wg := sync.WaitGroup{}
+	for _, player := range res {
+		apiVersion, _, _ := strings.Cut(player.APIVersion, ".")
+		if player.Reachable == nil || !*player.Reachable || player.APIAvailable == nil || !*player.APIAvailable || apiVersion == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(player *MetricsFreeboxPlayer) {
+			defer wg.Done()
+			status := new(MetricsFreeboxPlayerStatus)
+
+			// http://mafreebox.freebox.fr/api/v5/player/1/api/v6/status/
+			if err := f.get(fmt.Sprintf("player/%d/api/v%s/status/", player.ID, apiVersion), status); err != nil {
+				log.Error.Println("Could not get the status of the player", player.ID, err)
+				return
+			}
+			player.Status = status
+		}(player)
+	}
+
+	wg.Wait()
+	return res, nil
+}
+
+// GetMetricsPvr https://dev.freebox.fr/sdk/os/pvr/
+// The permission "pvr" is required
+func (f *FreeboxClientV5) GetMetricsPvr() (*MetricsFreeboxPvr, error) {
+	res := &MetricsFreeboxPvr{
+		Config: new(MetricsFreeboxPvrConfig),
+	}
+
+	// http://mafreebox.freebox.fr/api/v5/pvr/config/
+	if err := f.get("pvr/config/", res.Config); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/pvr/programmed/
+	if err := f.get("pvr/programmed/", &res.Programmed); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/pvr/finished/
+	if err := f.get("pvr/finished/", &res.Finished); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMetricsRepeater https://dev.freebox.fr/sdk/os/repeater/
+func (f *FreeboxClientV5) GetMetricsRepeater() ([]*MetricsFreeboxRepeater, error) {
+	res := []*MetricsFreeboxRepeater{}
+
+	// http://mafreebox.freebox.fr/api/v5/repeater/
+	if err := f.get("repeater/", &res); err != nil {
+		return nil, err
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(res))
+
+	for _, repeater := range res {
+		go func(repeater *MetricsFreeboxRepeater) {
+			defer wg.Done()
+
+			// http://mafreebox.freebox.fr/api/v5/repeater/1/hosts/
+			if err := f.get(fmt.Sprintf("repeater/%d/hosts/", repeater.ID), &repeater.Hosts); err != nil {
+				log.Error.Println("Could not get the hosts of repeater", repeater.ID, err)
+			}
+		}(repeater)
+	}
+
+	wg.Wait()
+	return res, nil
+}
+
+// GetMetricsParental https://dev.freebox.fr/sdk/os/network_control/
+// The permission "parental" is required
+func (f *FreeboxClientV5) GetMetricsParental() (*MetricsFreeboxParental, error) {
+	res := &MetricsFreeboxParental{}
+
+	// http://mafreebox.freebox.fr/api/v5/profile/
+	if err := f.get("profile/", &res.Profiles); err != nil {
+		return nil, err
+	}
+	// http://mafreebox.freebox.fr/api/v5/network_control/
+	if err := f.get("network_control/", &res.NetworkControls); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (f *FreeboxClientV5) get(path string, out interface{}) error {
 	return f.conn.Get(f.queryVersion, path, out)
 }
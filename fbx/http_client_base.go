@@ -15,6 +15,11 @@ import (
 var (
 	errAuthRequired = errors.New("auth_required")
 	errInvalidToken = errors.New("invalid_token")
+
+	// ErrInsufficientRights is returned when a permission has not been granted to the application
+	ErrInsufficientRights = errors.New("insufficient_rights")
+	// ErrUnsupported is returned when the API or the device is not available on this model or firmware
+	ErrUnsupported = errors.New("unsupported")
 )
 
 type FreeboxHttpClientBase struct {
@@ -23,9 +28,10 @@ type FreeboxHttpClientBase struct {
 }
 
 type freeboxAPIResponse struct {
-	Success   bool   `json:"success"`
-	Message   string `json:"msg"`
-	ErrorCode string `json:"error_code"`
+	Success      bool   `json:"success"`
+	Message      string `json:"msg"`
+	ErrorCode    string `json:"error_code"`
+	MissingRight string `json:"missing_right"`
 }
 
 func NewFreeboxHttpClientBase(client HttpClientInternal) FreeboxHttpClient {
@@ -94,6 +100,10 @@ func (f *FreeboxHttpClientBase) do(req *http.Request, out interface{}) error {
 			return errAuthRequired
 		case errInvalidToken.Error():
 			return errInvalidToken
+		case ErrInsufficientRights.Error():
+			return fmt.Errorf("%s %s: %w: the permission \"%s\" must be granted to the application in the Freebox OS settings", req.Method, req.URL, ErrInsufficientRights, apiResponse.MissingRight)
+		case "invalid_request", "nodev":
+			return fmt.Errorf("%s %s: %w error_code=%s msg=%s", req.Method, req.URL, ErrUnsupported, apiResponse.ErrorCode, apiResponse.Message)
 		default:
 			return fmt.Errorf("%s %s error_code=%s msg=%s", req.Method, req.URL, apiResponse.ErrorCode, apiResponse.Message)
 		}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
+	"time"
+	_ "time/tzdata" // the container images may not provide the time zone database
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -29,8 +32,10 @@ func main() {
 	debugPtr := flag.Bool("debug", false, "enable the debug mode")
 	hostDetailsPtr := flag.Bool("hostDetails", false, "get details about the hosts connected to wifi and ethernet. This increases the number of metrics")
 	httpDiscoveryPtr := flag.Bool("httpDiscovery", false, "use http://mafreebox.freebox.fr/api_version to discover the Freebox at the first run (by default: use mDNS)")
+	hostNameSourcesPtr := flag.String("hostNameSources", "primary", "comma separated list of the sources used to name the LAN hosts, by order of preference: "+strings.Join(hostNameSources, ", ")+". The resolved name is in the label primary_name of the freebox_lan_host_active_* metrics")
 	apiVersionPtr := flag.Int("apiVersion", 0, "Force the API version (by default use the latest one)")
 	listenPtr := flag.String("listen", ":9091", "listen to address")
+	timeZonePtr := flag.String("timeZone", "Europe/Paris", "time zone of the Freebox, used to follow its Wi-Fi planning")
 	flag.Parse()
 
 	args := flag.Args()
@@ -43,6 +48,18 @@ func main() {
 		usage()
 		os.Exit(1)
 	}
+	hostNamePolicy, err := parseHostNamePolicy(*hostNameSourcesPtr)
+	if err != nil {
+		fmt.Fprintf(flag.CommandLine.Output(), "ERROR: %v\n", err)
+		usage()
+		os.Exit(1)
+	}
+	location, err := time.LoadLocation(*timeZonePtr)
+	if err != nil {
+		fmt.Fprintf(flag.CommandLine.Output(), "ERROR: %v\n", err)
+		usage()
+		os.Exit(1)
+	}
 	if *debugPtr {
 		log.InitDebug()
 	} else {
@@ -53,7 +70,7 @@ func main() {
 		discovery = fbx.FreeboxDiscoveryHTTP
 	}
 
-	collector := NewCollector(args[0], discovery, *apiVersionPtr, *hostDetailsPtr, *debugPtr)
+	collector := NewCollector(args[0], discovery, *apiVersionPtr, *hostDetailsPtr, hostNamePolicy, location, *debugPtr)
 	defer collector.Close()
 
 	prometheus.MustRegister(collector)